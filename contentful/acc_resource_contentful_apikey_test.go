@@ -65,7 +65,7 @@ func testAccCheckContentfulAPIKeyExists(n string, apiKey *contentful.APIKey) res
 			return fmt.Errorf("no api key ID is set")
 		}
 
-		client := testAccProvider.Meta().(*contentful.Client)
+		client := testAccProvider.Meta().(*providerMeta).client
 
 		contentfulAPIKey, err := client.APIKeys.Get(context.Background(), spaceID, apiKeyID)
 		if err != nil {
@@ -111,7 +111,7 @@ func testAccContentfulAPIKeyDestroy(s *terraform.State) error {
 			return fmt.Errorf("no apikey ID is set")
 		}
 
-		client := testAccProvider.Meta().(*contentful.Client)
+		client := testAccProvider.Meta().(*providerMeta).client
 
 		_, err := client.APIKeys.Get(context.Background(), spaceID, apiKeyID)
 		if _, ok := err.(contentful.NotFoundError); ok {
@@ -52,7 +52,7 @@ func testAccCheckContentfulAssetExists(n string, asset *contentful.Asset) resour
 			return fmt.Errorf("no space_id is set")
 		}
 
-		client := testAccProvider.Meta().(*contentful.Client)
+		client := testAccProvider.Meta().(*providerMeta).client
 
 		contentfulAsset, err := client.Assets.Get(context.Background(), spaceID, rs.Primary.ID)
 		if err != nil {
@@ -94,7 +94,7 @@ func testAccContentfulAssetDestroy(s *terraform.State) error {
 		}
 
 		// sdk client
-		client := testAccProvider.Meta().(*contentful.Client)
+		client := testAccProvider.Meta().(*providerMeta).client
 
 		asset, _ := client.Assets.Get(context.Background(), spaceID, rs.Primary.ID)
 		if asset == nil {
@@ -0,0 +1,110 @@
+package contentful
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+func TestAccContentfulBulkAction_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccContentfulBulkActionConfig("publish"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("contentful_bulk_action.mybulkaction", "bulk_action_ids.#", "1"),
+					testAccCheckContentfulBulkActionPublished("contentful_entry.myentry", true),
+				),
+			},
+			{
+				Config: testAccContentfulBulkActionConfig("unpublish"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("contentful_bulk_action.mybulkaction", "bulk_action_ids.#", "1"),
+					testAccCheckContentfulBulkActionPublished("contentful_entry.myentry", false),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckContentfulBulkActionPublished(n string, published bool) resource.TestCheckFunc {
+	env := &contentful.Environment{
+		Sys: &contentful.Sys{
+			ID: envID,
+			Space: &contentful.Space{
+				Sys: &contentful.Sys{
+					ID: spaceID,
+				},
+			},
+		},
+	}
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not Found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*providerMeta).client
+
+		entry, err := client.Entries.Get(context.Background(), env, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if (entry.Sys.PublishedAt != "") != published {
+			return fmt.Errorf("entry published state does not match: %s, %v", entry.Sys.PublishedAt, published)
+		}
+
+		return nil
+	}
+}
+
+func testAccContentfulBulkActionConfig(action string) string {
+	return `
+resource "contentful_contenttype" "mycontenttype" {
+  space_id = "` + spaceID + `"
+  env_id = "` + envID + `"
+  name = "tf_test_bulk_action"
+  description = "Terraform Acc Test Content Type"
+  display_field = "field1"
+  field {
+    id        = "field1"
+    name      = "Field 1"
+    type      = "Text"
+    required  = true
+  }
+}
+
+resource "contentful_entry" "myentry" {
+  entry_id = "mytestbulkactionentry"
+  space_id = "` + spaceID + `"
+  env_id = "` + envID + `"
+  contenttype_id = contentful_contenttype.mycontenttype.id
+  locale = "en-US"
+  field {
+    id = "field1"
+    content = "Hello, World!"
+    locale = "en-US"
+  }
+  published = false
+  archived  = false
+
+  lifecycle {
+    ignore_changes = [published]
+  }
+}
+
+resource "contentful_bulk_action" "mybulkaction" {
+  space_id = "` + spaceID + `"
+  env_id   = "` + envID + `"
+  action   = "` + action + `"
+  entries  = [contentful_entry.myentry.id]
+}
+`
+}
@@ -67,7 +67,7 @@ func testAccCheckContentfulEntryExists(n string, entry *contentful.Entry) resour
 			return fmt.Errorf("no contenttype_id is set")
 		}
 
-		client := testAccProvider.Meta().(*contentful.Client)
+		client := testAccProvider.Meta().(*providerMeta).client
 
 		contentfulEntry, err := client.Entries.Get(context.Background(), env, rs.Primary.ID)
 		if err != nil {
@@ -120,7 +120,7 @@ func testAccContentfulEntryDestroy(s *terraform.State) error {
 		}
 
 		// sdk client
-		client := testAccProvider.Meta().(*contentful.Client)
+		client := testAccProvider.Meta().(*providerMeta).client
 
 		entry, _ := client.Entries.Get(context.Background(), env, rs.Primary.ID)
 		if entry == nil {
@@ -59,7 +59,7 @@ func testAccCheckContentfulEnvironmentExists(n string, environment *contentful.E
 			return fmt.Errorf("no name is set")
 		}
 
-		client := testAccProvider.Meta().(*contentful.Client)
+		client := testAccProvider.Meta().(*providerMeta).client
 
 		contentfulEnvironment, err := client.Environments.Get(context.Background(), spaceID, rs.Primary.ID)
 		if err != nil {
@@ -98,7 +98,7 @@ func testAccContentfulEnvironmentDestroy(s *terraform.State) error {
 			return fmt.Errorf("no locale ID is set")
 		}
 
-		client := testAccProvider.Meta().(*contentful.Client)
+		client := testAccProvider.Meta().(*providerMeta).client
 
 		_, err := client.Locales.Get(context.Background(), spaceID, localeID)
 		if _, ok := err.(contentful.NotFoundError); ok {
@@ -0,0 +1,165 @@
+package contentful
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+func TestAccContentfulRelease_Basic(t *testing.T) {
+	var release Release
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccContentfulReleaseDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccContentfulReleaseConfig("validate"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckContentfulReleaseExists("contentful_release.myrelease", &release),
+					testAccCheckContentfulReleaseAttributes(&release, map[string]interface{}{
+						"title":    "Terraform Acc Test Release",
+						"entities": 1,
+					}),
+					resource.TestCheckResourceAttr("contentful_release.myrelease", "action_status", "succeeded"),
+				),
+			},
+			{
+				Config: testAccContentfulReleaseConfig("publish"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckContentfulReleaseExists("contentful_release.myrelease", &release),
+					testAccCheckContentfulReleaseAttributes(&release, map[string]interface{}{
+						"title":    "Terraform Acc Test Release",
+						"entities": 1,
+					}),
+					resource.TestCheckResourceAttr("contentful_release.myrelease", "action_status", "succeeded"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckContentfulReleaseExists(n string, release *Release) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not Found: %s", n)
+		}
+
+		spaceID := rs.Primary.Attributes["space_id"]
+		if spaceID == "" {
+			return fmt.Errorf("no space_id is set")
+		}
+
+		envID := rs.Primary.Attributes["env_id"]
+		if envID == "" {
+			return fmt.Errorf("no env_id is set")
+		}
+
+		client := &releasesService{c: newCMAClient(testAccProvider.Meta().(*providerMeta).client)}
+
+		contentfulRelease, err := client.Get(context.Background(), spaceID, envID, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*release = *contentfulRelease
+
+		return nil
+	}
+}
+
+func testAccCheckContentfulReleaseAttributes(release *Release, attrs map[string]interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		title := attrs["title"].(string)
+		if release.Title != title {
+			return fmt.Errorf("release title does not match: %s, %s", release.Title, title)
+		}
+
+		entities := attrs["entities"].(int)
+		if len(release.Entities.Items) != entities {
+			return fmt.Errorf("release entities does not match: %d, %d", len(release.Entities.Items), entities)
+		}
+
+		return nil
+	}
+}
+
+func testAccContentfulReleaseDestroy(s *terraform.State) error {
+	client := &releasesService{c: newCMAClient(testAccProvider.Meta().(*providerMeta).client)}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "contentful_release" {
+			continue
+		}
+
+		spaceID := rs.Primary.Attributes["space_id"]
+		if spaceID == "" {
+			return fmt.Errorf("no space_id is set")
+		}
+
+		envID := rs.Primary.Attributes["env_id"]
+		if envID == "" {
+			return fmt.Errorf("no env_id is set")
+		}
+
+		_, err := client.Get(context.Background(), spaceID, envID, rs.Primary.ID)
+		if _, ok := err.(contentful.NotFoundError); ok {
+			continue
+		}
+
+		return fmt.Errorf("release still exists with id: %s", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccContentfulReleaseConfig(action string) string {
+	return `
+resource "contentful_contenttype" "mycontenttype" {
+  space_id = "` + spaceID + `"
+  env_id = "` + envID + `"
+  name = "tf_test_release"
+  description = "Terraform Acc Test Content Type"
+  display_field = "field1"
+  field {
+    id        = "field1"
+    name      = "Field 1"
+    type      = "Text"
+    required  = true
+  }
+}
+
+resource "contentful_entry" "myentry" {
+  entry_id = "mytestreleaseentry"
+  space_id = "` + spaceID + `"
+  env_id = "` + envID + `"
+  contenttype_id = contentful_contenttype.mycontenttype.id
+  locale = "en-US"
+  field {
+    id = "field1"
+    content = "Hello, World!"
+    locale = "en-US"
+  }
+  published = false
+  archived  = false
+
+  lifecycle {
+    ignore_changes = [published]
+  }
+}
+
+resource "contentful_release" "myrelease" {
+  space_id = "` + spaceID + `"
+  env_id   = "` + envID + `"
+  title    = "Terraform Acc Test Release"
+  entries  = [contentful_entry.myentry.id]
+  action   = "` + action + `"
+}
+`
+}
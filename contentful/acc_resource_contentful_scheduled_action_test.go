@@ -0,0 +1,165 @@
+package contentful
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+func TestAccContentfulScheduledAction_Basic(t *testing.T) {
+	var scheduledAction ScheduledAction
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccContentfulScheduledActionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccContentfulScheduledActionConfig("2099-01-01T00:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckContentfulScheduledActionExists("contentful_scheduled_action.myaction", &scheduledAction),
+					testAccCheckContentfulScheduledActionAttributes(&scheduledAction, map[string]interface{}{
+						"action": "publish",
+						"status": "scheduled",
+					}),
+				),
+			},
+			{
+				Config: testAccContentfulScheduledActionConfig("2099-01-02T00:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckContentfulScheduledActionExists("contentful_scheduled_action.myaction", &scheduledAction),
+					testAccCheckContentfulScheduledActionAttributes(&scheduledAction, map[string]interface{}{
+						"action": "publish",
+						"status": "scheduled",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckContentfulScheduledActionExists(n string, scheduledAction *ScheduledAction) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not Found: %s", n)
+		}
+
+		spaceID := rs.Primary.Attributes["space_id"]
+		if spaceID == "" {
+			return fmt.Errorf("no space_id is set")
+		}
+
+		envID := rs.Primary.Attributes["env_id"]
+		if envID == "" {
+			return fmt.Errorf("no env_id is set")
+		}
+
+		client := &scheduledActionsService{c: newCMAClient(testAccProvider.Meta().(*providerMeta).client)}
+
+		contentfulScheduledAction, err := client.Get(context.Background(), spaceID, envID, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		*scheduledAction = *contentfulScheduledAction
+
+		return nil
+	}
+}
+
+func testAccCheckContentfulScheduledActionAttributes(scheduledAction *ScheduledAction, attrs map[string]interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		action := attrs["action"].(string)
+		if scheduledAction.Action != action {
+			return fmt.Errorf("scheduled action action does not match: %s, %s", scheduledAction.Action, action)
+		}
+
+		status := attrs["status"].(string)
+		if scheduledAction.Sys.Status != status {
+			return fmt.Errorf("scheduled action status does not match: %s, %s", scheduledAction.Sys.Status, status)
+		}
+
+		return nil
+	}
+}
+
+func testAccContentfulScheduledActionDestroy(s *terraform.State) error {
+	client := &scheduledActionsService{c: newCMAClient(testAccProvider.Meta().(*providerMeta).client)}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "contentful_scheduled_action" {
+			continue
+		}
+
+		spaceID := rs.Primary.Attributes["space_id"]
+		if spaceID == "" {
+			return fmt.Errorf("no space_id is set")
+		}
+
+		envID := rs.Primary.Attributes["env_id"]
+		if envID == "" {
+			return fmt.Errorf("no env_id is set")
+		}
+
+		scheduledAction, err := client.Get(context.Background(), spaceID, envID, rs.Primary.ID)
+		if _, ok := err.(contentful.NotFoundError); ok {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if scheduledAction.Sys.Status == "scheduled" {
+			return fmt.Errorf("scheduled action is still scheduled with id: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccContentfulScheduledActionConfig(scheduledFor string) string {
+	return `
+resource "contentful_contenttype" "mycontenttype" {
+  space_id = "` + spaceID + `"
+  env_id = "` + envID + `"
+  name = "tf_test_scheduled_action"
+  description = "Terraform Acc Test Content Type"
+  display_field = "field1"
+  field {
+    id        = "field1"
+    name      = "Field 1"
+    type      = "Text"
+    required  = true
+  }
+}
+
+resource "contentful_entry" "myentry" {
+  entry_id = "mytestscheduledentry"
+  space_id = "` + spaceID + `"
+  env_id = "` + envID + `"
+  contenttype_id = contentful_contenttype.mycontenttype.id
+  locale = "en-US"
+  field {
+    id = "field1"
+    content = "Hello, World!"
+    locale = "en-US"
+  }
+  published = false
+  archived  = false
+}
+
+resource "contentful_scheduled_action" "myaction" {
+  space_id      = "` + spaceID + `"
+  env_id        = "` + envID + `"
+  entity_id     = contentful_entry.myentry.id
+  action        = "publish"
+  scheduled_for = "` + scheduledFor + `"
+  timezone      = "Europe/Berlin"
+}
+`
+}
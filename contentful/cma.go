@@ -0,0 +1,203 @@
+package contentful
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	contentful "github.com/kitagry/contentful-go"
+)
+
+// cmaClient sends requests to Content Management API endpoints which are not covered by contentful-go.
+// It shares the base URL and headers with the contentful-go client, so both always talk to the same host.
+type cmaClient struct {
+	client     *contentful.Client
+	httpClient *http.Client
+}
+
+// defaultHTTPClient is shared by the contentful-go clients and cmaClient, so that all requests retry rate limits alike.
+var defaultHTTPClient = &http.Client{
+	Transport: &rateLimitTransport{next: http.DefaultTransport},
+}
+
+func newCMAClient(client *contentful.Client) *cmaClient {
+	return &cmaClient{
+		client:     client,
+		httpClient: defaultHTTPClient,
+	}
+}
+
+func (c *cmaClient) newRequest(ctx context.Context, method, path string, query url.Values, body interface{}) (*http.Request, error) {
+	u, err := url.Parse(c.client.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path
+	if query != nil {
+		u.RawQuery = query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range c.client.Headers {
+		req.Header.Set(key, value)
+	}
+
+	return req, nil
+}
+
+func (c *cmaClient) do(req *http.Request, v interface{}) error {
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 400 {
+		return decodeErrorResponse(res)
+	}
+
+	defer res.Body.Close()
+	if v == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// listAll gets every page of the collection at path, filtered by query, and decodes all items into v.
+func (c *cmaClient) listAll(ctx context.Context, path string, query url.Values, v interface{}) error {
+	const limit = 100
+	var items []json.RawMessage
+	for skip := 0; ; skip += limit {
+		q := url.Values{}
+		for k, values := range query {
+			q[k] = values
+		}
+		q.Set("skip", strconv.Itoa(skip))
+		q.Set("limit", strconv.Itoa(limit))
+		q.Set("order", "sys.createdAt")
+
+		req, err := c.newRequest(ctx, http.MethodGet, path, q, nil)
+		if err != nil {
+			return err
+		}
+
+		var page struct {
+			Total int               `json:"total"`
+			Items []json.RawMessage `json:"items"`
+		}
+		if err := c.do(req, &page); err != nil {
+			return err
+		}
+		items = append(items, page.Items...)
+		if skip+limit >= page.Total {
+			break
+		}
+	}
+
+	b, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// rateLimitTransport retries rate limited requests once the rate limit resets.
+// Waiting honours the context of the request, so that requests give up at the timeout of the operation.
+// A rate limited response is passed on without its reset header, which stops contentful-go from sleeping regardless of the context.
+type rateLimitTransport struct {
+	next http.RoundTripper
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if req.Body != nil && req.GetBody == nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(ctx)
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	for {
+		res, err := t.next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		wait, retry := rateLimitReset(res)
+		if !retry {
+			return res, nil
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			res.Header.Del("X-Contentful-RateLimit-Reset")
+			return res, nil
+		}
+		res.Body.Close()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+	}
+}
+
+// rateLimitReset returns how long to wait before retrying a rate limited request.
+func rateLimitReset(res *http.Response) (time.Duration, bool) {
+	if res.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	seconds, err := strconv.Atoi(res.Header.Get("X-Contentful-RateLimit-Reset"))
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// decodeErrorResponse converts an API error into the same error types contentful-go returns,
+// so that callers can handle both with contentfulErrorToDiagnostic.
+func decodeErrorResponse(res *http.Response) error {
+	defer res.Body.Close()
+
+	var e contentful.ErrorResponse
+	if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
+		return err
+	}
+
+	if e.Sys != nil && e.Sys.ID == "NotFound" {
+		return contentful.NotFoundError{}
+	}
+	return e
+}
@@ -0,0 +1,107 @@
+package contentful
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	contentful "github.com/kitagry/contentful-go"
+)
+
+// bulkActionMaxItems is the maximum number of entities the API accepts in a single bulk action.
+const bulkActionMaxItems = 200
+
+// BulkAction model
+type BulkAction struct {
+	Sys    ActionSys    `json:"sys"`
+	Action string       `json:"action"`
+	Error  *ActionError `json:"error,omitempty"`
+}
+
+type bulkActionsService struct {
+	c *cmaClient
+}
+
+// Publish starts publishing the entities. Each link must contain the current version of the entity.
+func (s *bulkActionsService) Publish(ctx context.Context, spaceID, environmentID string, entities EntityLinks) (*BulkAction, error) {
+	return s.create(ctx, spaceID, environmentID, "publish", map[string]interface{}{"entities": entities})
+}
+
+// Unpublish starts unpublishing the entities
+func (s *bulkActionsService) Unpublish(ctx context.Context, spaceID, environmentID string, entities EntityLinks) (*BulkAction, error) {
+	return s.create(ctx, spaceID, environmentID, "unpublish", map[string]interface{}{"entities": entities})
+}
+
+// Validate starts validating the entities for publishing
+func (s *bulkActionsService) Validate(ctx context.Context, spaceID, environmentID string, entities EntityLinks) (*BulkAction, error) {
+	return s.create(ctx, spaceID, environmentID, "validate", map[string]interface{}{"action": "publish", "entities": entities})
+}
+
+func (s *bulkActionsService) create(ctx context.Context, spaceID, environmentID, action string, body interface{}) (*BulkAction, error) {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/bulk_actions/%s", spaceID, environmentID, action)
+
+	req, err := s.c.newRequest(ctx, http.MethodPost, path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var bulkAction BulkAction
+	if err := s.c.do(req, &bulkAction); err != nil {
+		return nil, err
+	}
+	return &bulkAction, nil
+}
+
+// GetAction returns a single bulk action
+func (s *bulkActionsService) GetAction(ctx context.Context, spaceID, environmentID, bulkActionID string) (*BulkAction, error) {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/bulk_actions/actions/%s", spaceID, environmentID, bulkActionID)
+
+	req, err := s.c.newRequest(ctx, http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var bulkAction BulkAction
+	if err := s.c.do(req, &bulkAction); err != nil {
+		return nil, err
+	}
+	return &bulkAction, nil
+}
+
+// Versions returns the current versions of the entries or assets with the given IDs.
+// linkType is either Entry or Asset. At most bulkActionMaxItems IDs can be requested at once.
+func (s *bulkActionsService) Versions(ctx context.Context, spaceID, environmentID, linkType string, ids []string) (map[string]int, error) {
+	collection := "entries"
+	if linkType == "Asset" {
+		collection = "assets"
+	}
+	path := fmt.Sprintf("/spaces/%s/environments/%s/%s", spaceID, environmentID, collection)
+	query := url.Values{
+		"sys.id[in]": []string{strings.Join(ids, ",")},
+		"select":     []string{"sys.id,sys.version"},
+		"limit":      []string{strconv.Itoa(bulkActionMaxItems)},
+	}
+
+	req, err := s.c.newRequest(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var col struct {
+		Items []struct {
+			Sys contentful.Sys `json:"sys"`
+		} `json:"items"`
+	}
+	if err := s.c.do(req, &col); err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string]int, len(col.Items))
+	for _, item := range col.Items {
+		versions[item.Sys.ID] = item.Sys.Version
+	}
+	return versions, nil
+}
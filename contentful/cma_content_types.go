@@ -0,0 +1,168 @@
+package contentful
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	contentful "github.com/kitagry/contentful-go"
+)
+
+// ContentType model. It differs from contentful-go in the fields, which keep their apiName.
+type ContentType struct {
+	Sys          *contentful.Sys      `json:"sys"`
+	Name         string               `json:"name,omitempty"`
+	Description  string               `json:"description,omitempty"`
+	Fields       []*ContentTypeField  `json:"fields,omitempty"`
+	DisplayField string               `json:"displayField,omitempty"`
+	Metadata     *ContentTypeMetadata `json:"metadata,omitempty"`
+}
+
+// ContentTypeMetadata model
+type ContentTypeMetadata struct {
+	Annotations *ContentTypeAnnotations `json:"annotations,omitempty"`
+	Taxonomy    []*TaxonomyValidation   `json:"taxonomy,omitempty"`
+}
+
+// ContentTypeAnnotations are links to the annotations of a content type and of its fields.
+// The annotations of fields are keyed by the ID of the field.
+type ContentTypeAnnotations struct {
+	ContentType      []contentful.Entity            `json:"ContentType,omitempty"`
+	ContentTypeField map[string][]contentful.Entity `json:"ContentTypeField,omitempty"`
+}
+
+// TaxonomyValidation limits the taxonomy concepts entries of the content type may be tagged with.
+// Sys links to a TaxonomyConcept or a TaxonomyConceptScheme.
+type TaxonomyValidation struct {
+	Sys      contentful.Sys `json:"sys"`
+	Required bool           `json:"required,omitempty"`
+}
+
+// ContentTypeField is a field of a content type.
+// ID never changes once the field is created, while APIName is the field ID shown in the web app and used by entries.
+type ContentTypeField struct {
+	contentful.Field
+	APIName          string                 `json:"apiName,omitempty"`
+	DefaultValue     map[string]interface{} `json:"defaultValue,omitempty"`
+	AllowedResources []*AllowedResource     `json:"allowedResources,omitempty"`
+}
+
+// AllowedResource is a kind of resource which a ResourceLink field may link to, such as entries of another space.
+type AllowedResource struct {
+	Type         string   `json:"type"`
+	Source       string   `json:"source"`
+	ContentTypes []string `json:"contentTypes"`
+}
+
+// UnmarshalJSON decodes the field with contentful-go and adds the properties contentful-go does not know.
+func (f *ContentTypeField) UnmarshalJSON(data []byte) error {
+	if err := f.Field.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	var payload struct {
+		APIName          string                 `json:"apiName"`
+		DefaultValue     map[string]interface{} `json:"defaultValue"`
+		AllowedResources []*AllowedResource     `json:"allowedResources"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	f.APIName = payload.APIName
+	f.DefaultValue = payload.DefaultValue
+	f.AllowedResources = payload.AllowedResources
+	return nil
+}
+
+func newAnnotationLinks(ids []string) []contentful.Entity {
+	links := make([]contentful.Entity, 0, len(ids))
+	for _, id := range ids {
+		links = append(links, contentful.Entity{Sys: contentful.Sys{Type: "Link", LinkType: "Annotation", ID: id}})
+	}
+	return links
+}
+
+// apiNameOf returns the field ID used by entries. Contentful omits apiName for fields which were never renamed.
+func apiNameOf(f *ContentTypeField) string {
+	if f.APIName != "" {
+		return f.APIName
+	}
+	return f.ID
+}
+
+type contentTypesService struct {
+	c *cmaClient
+}
+
+func contentTypePath(env *contentful.Environment) string {
+	return fmt.Sprintf("/spaces/%s/environments/%s/content_types", env.Sys.Space.Sys.ID, env.Sys.ID)
+}
+
+// Get returns a single content type
+func (s *contentTypesService) Get(ctx context.Context, env *contentful.Environment, contentTypeID string) (*ContentType, error) {
+	req, err := s.c.newRequest(ctx, http.MethodGet, contentTypePath(env)+"/"+contentTypeID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ct ContentType
+	if err := s.c.do(req, &ct); err != nil {
+		return nil, err
+	}
+	return &ct, nil
+}
+
+// Upsert updates or creates a new content type
+func (s *contentTypesService) Upsert(ctx context.Context, env *contentful.Environment, ct *ContentType) error {
+	path := contentTypePath(env)
+	method := http.MethodPost
+	version := 1
+	if ct.Sys != nil && ct.Sys.ID != "" {
+		path += "/" + ct.Sys.ID
+		method = http.MethodPut
+		if ct.Sys.Version != 0 {
+			version = ct.Sys.Version
+		}
+	}
+
+	req, err := s.c.newRequest(ctx, method, path, nil, ct)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Contentful-Version", strconv.Itoa(version))
+
+	return s.c.do(req, ct)
+}
+
+// Activate publishes the current version of the content type
+func (s *contentTypesService) Activate(ctx context.Context, env *contentful.Environment, ct *ContentType) error {
+	return s.send(ctx, http.MethodPut, contentTypePath(env)+"/"+ct.Sys.ID+"/published", ct)
+}
+
+// Deactivate unpublishes the content type
+func (s *contentTypesService) Deactivate(ctx context.Context, env *contentful.Environment, ct *ContentType) error {
+	return s.send(ctx, http.MethodDelete, contentTypePath(env)+"/"+ct.Sys.ID+"/published", ct)
+}
+
+// Delete the content type
+func (s *contentTypesService) Delete(ctx context.Context, env *contentful.Environment, ct *ContentType) error {
+	req, err := s.c.newRequest(ctx, http.MethodDelete, contentTypePath(env)+"/"+ct.Sys.ID, nil, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Contentful-Version", strconv.Itoa(ct.Sys.Version))
+
+	return s.c.do(req, nil)
+}
+
+func (s *contentTypesService) send(ctx context.Context, method, path string, ct *ContentType) error {
+	req, err := s.c.newRequest(ctx, method, path, nil, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Contentful-Version", strconv.Itoa(ct.Sys.Version))
+
+	return s.c.do(req, ct)
+}
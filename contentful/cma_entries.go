@@ -0,0 +1,46 @@
+package contentful
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	contentful "github.com/kitagry/contentful-go"
+)
+
+// EntryCollection is a page of entries
+type EntryCollection struct {
+	Total int                 `json:"total"`
+	Skip  int                 `json:"skip"`
+	Limit int                 `json:"limit"`
+	Items []*contentful.Entry `json:"items"`
+}
+
+// entriesService queries entries, which contentful-go only returns as an untyped collection.
+type entriesService struct {
+	c *cmaClient
+}
+
+// ListByContentType returns a page of the entries of a content type, including drafts and archived entries
+func (s *entriesService) ListByContentType(ctx context.Context, spaceID, environmentID, contentTypeID string, skip, limit int) (*EntryCollection, error) {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/entries", spaceID, environmentID)
+	query := url.Values{
+		"content_type": []string{contentTypeID},
+		"skip":         []string{strconv.Itoa(skip)},
+		"limit":        []string{strconv.Itoa(limit)},
+		"order":        []string{"sys.createdAt"},
+	}
+
+	req, err := s.c.newRequest(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var col EntryCollection
+	if err := s.c.do(req, &col); err != nil {
+		return nil, err
+	}
+	return &col, nil
+}
@@ -0,0 +1,143 @@
+package contentful
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	contentful "github.com/kitagry/contentful-go"
+)
+
+// EntityDocument is an entity of an environment as JSON. It is used for entities which are passed on as they are,
+// such as those of a contentful-export file, so that properties contentful-go does not know are kept.
+type EntityDocument map[string]interface{}
+
+// Sys returns the sys of the entity, or an empty sys.
+func (doc EntityDocument) Sys() map[string]interface{} {
+	sys, _ := doc["sys"].(map[string]interface{})
+	if sys == nil {
+		return map[string]interface{}{}
+	}
+	return sys
+}
+
+// ID returns sys.id of the entity.
+func (doc EntityDocument) ID() string {
+	id, _ := doc.Sys()["id"].(string)
+	return id
+}
+
+// Version returns sys.version of the entity.
+func (doc EntityDocument) Version() int {
+	return intValue(doc.Sys()["version"])
+}
+
+// PublishedVersion returns sys.publishedVersion of the entity, which is 0 unless it is published.
+func (doc EntityDocument) PublishedVersion() int {
+	return intValue(doc.Sys()["publishedVersion"])
+}
+
+// linkID returns the ID of a link, such as sys.contentType of an entry.
+func linkID(v interface{}) string {
+	link, _ := v.(map[string]interface{})
+	return EntityDocument(link).ID()
+}
+
+func intValue(v interface{}) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	}
+	return 0
+}
+
+// environmentEntitiesService reads and writes entities of an environment by their path below the environment,
+// such as entries/<id> or content_types/<id>/editor_interface.
+type environmentEntitiesService struct {
+	c *cmaClient
+}
+
+func environmentPath(env *contentful.Environment, path string) string {
+	return fmt.Sprintf("/spaces/%s/environments/%s/%s", env.Sys.Space.Sys.ID, env.Sys.ID, path)
+}
+
+// Get returns the entity at path
+func (s *environmentEntitiesService) Get(ctx context.Context, env *contentful.Environment, path string) (EntityDocument, error) {
+	req, err := s.c.newRequest(ctx, http.MethodGet, environmentPath(env, path), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc EntityDocument
+	if err := s.c.do(req, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// List returns every entity of a collection, such as locales
+func (s *environmentEntitiesService) List(ctx context.Context, env *contentful.Environment, collection string) ([]EntityDocument, error) {
+	var docs []EntityDocument
+	if err := s.c.listAll(ctx, environmentPath(env, collection), nil, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// Put creates or updates the entity at path. version is the current version of the entity, or 0 to create it.
+// Actions such as publishing are a Put to the path of the action without doc.
+// contentTypeID is the content type of an entry which is created, and empty otherwise.
+func (s *environmentEntitiesService) Put(ctx context.Context, env *contentful.Environment, path string, version int, doc EntityDocument, contentTypeID string) (EntityDocument, error) {
+	var body interface{}
+	if doc != nil {
+		body = doc
+	}
+	req, err := s.c.newRequest(ctx, http.MethodPut, environmentPath(env, path), nil, body)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 {
+		req.Header.Set("X-Contentful-Version", strconv.Itoa(version))
+	}
+	if contentTypeID != "" {
+		req.Header.Set("X-Contentful-Content-Type", contentTypeID)
+	}
+
+	var result EntityDocument
+	if err := s.c.do(req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Post creates an entity in a collection with an ID generated by Contentful
+func (s *environmentEntitiesService) Post(ctx context.Context, env *contentful.Environment, collection string, doc EntityDocument) (EntityDocument, error) {
+	req, err := s.c.newRequest(ctx, http.MethodPost, environmentPath(env, collection), nil, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var result EntityDocument
+	if err := s.c.do(req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Delete deletes the entity at path, or undoes an action such as publishing when path is the path of the action.
+func (s *environmentEntitiesService) Delete(ctx context.Context, env *contentful.Environment, path string, version int) (EntityDocument, error) {
+	req, err := s.c.newRequest(ctx, http.MethodDelete, environmentPath(env, path), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Contentful-Version", strconv.Itoa(version))
+
+	var result EntityDocument
+	if err := s.c.do(req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
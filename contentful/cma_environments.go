@@ -0,0 +1,44 @@
+package contentful
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	contentful "github.com/kitagry/contentful-go"
+)
+
+// Statuses of an environment. An environment is queued while it is copied from its source environment.
+const (
+	environmentStatusQueued = "queued"
+	environmentStatusReady  = "ready"
+	environmentStatusFailed = "failed"
+)
+
+// environmentsService gets the status of environments, which contentful-go does not decode.
+type environmentsService struct {
+	c *cmaClient
+}
+
+// GetStatus returns the status of an environment
+func (s *environmentsService) GetStatus(ctx context.Context, spaceID, environmentID string) (string, error) {
+	path := fmt.Sprintf("/spaces/%s/environments/%s", spaceID, environmentID)
+
+	req, err := s.c.newRequest(ctx, http.MethodGet, path, nil, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var environment struct {
+		Sys struct {
+			Status *contentful.Entity `json:"status"`
+		} `json:"sys"`
+	}
+	if err := s.c.do(req, &environment); err != nil {
+		return "", err
+	}
+	if environment.Sys.Status == nil {
+		return "", nil
+	}
+	return environment.Sys.Status.Sys.ID, nil
+}
@@ -0,0 +1,173 @@
+package contentful
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	contentful "github.com/kitagry/contentful-go"
+)
+
+// Release model
+type Release struct {
+	Sys      *contentful.Sys `json:"sys,omitempty"`
+	Title    string          `json:"title"`
+	Entities EntityLinks     `json:"entities"`
+}
+
+// EntityLinks is an array of links to entries and assets
+type EntityLinks struct {
+	Sys   contentful.Sys      `json:"sys"`
+	Items []contentful.Entity `json:"items"`
+}
+
+// ReleaseAction model
+type ReleaseAction struct {
+	Sys    ActionSys    `json:"sys"`
+	Action string       `json:"action"`
+	Error  *ActionError `json:"error,omitempty"`
+}
+
+// ActionSys model
+type ActionSys struct {
+	ID      string `json:"id,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Version int    `json:"version,omitempty"`
+	Status  string `json:"status,omitempty"`
+}
+
+// ActionError is the error of a failed release or bulk action
+type ActionError struct {
+	Sys     *contentful.Sys     `json:"sys,omitempty"`
+	Message string              `json:"message,omitempty"`
+	Details *ActionErrorDetails `json:"details,omitempty"`
+}
+
+// ActionErrorDetails model
+type ActionErrorDetails struct {
+	Errors []*EntityError `json:"errors,omitempty"`
+}
+
+// EntityError is the error of a single entity in a release or bulk action
+type EntityError struct {
+	Entity contentful.Entity        `json:"entity"`
+	Error  contentful.ErrorResponse `json:"error"`
+}
+
+func newEntityLinks(entryIDs, assetIDs []string) EntityLinks {
+	links := EntityLinks{
+		Sys:   contentful.Sys{Type: "Array"},
+		Items: make([]contentful.Entity, 0, len(entryIDs)+len(assetIDs)),
+	}
+	for _, id := range entryIDs {
+		links.Items = append(links.Items, contentful.Entity{Sys: contentful.Sys{Type: "Link", LinkType: "Entry", ID: id}})
+	}
+	for _, id := range assetIDs {
+		links.Items = append(links.Items, contentful.Entity{Sys: contentful.Sys{Type: "Link", LinkType: "Asset", ID: id}})
+	}
+	return links
+}
+
+type releasesService struct {
+	c *cmaClient
+}
+
+// Get returns a single release
+func (s *releasesService) Get(ctx context.Context, spaceID, environmentID, releaseID string) (*Release, error) {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/releases/%s", spaceID, environmentID, releaseID)
+
+	req, err := s.c.newRequest(ctx, http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var release Release
+	if err := s.c.do(req, &release); err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+// Upsert updates or creates a new release
+func (s *releasesService) Upsert(ctx context.Context, spaceID, environmentID string, release *Release) error {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/releases", spaceID, environmentID)
+	method := http.MethodPost
+	if release.Sys != nil && release.Sys.ID != "" {
+		path += "/" + release.Sys.ID
+		method = http.MethodPut
+	}
+
+	req, err := s.c.newRequest(ctx, method, path, nil, release)
+	if err != nil {
+		return err
+	}
+
+	if method == http.MethodPut {
+		req.Header.Set("X-Contentful-Version", strconv.Itoa(release.Sys.Version))
+	}
+
+	return s.c.do(req, release)
+}
+
+// Delete the release
+func (s *releasesService) Delete(ctx context.Context, spaceID, environmentID, releaseID string) error {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/releases/%s", spaceID, environmentID, releaseID)
+
+	req, err := s.c.newRequest(ctx, http.MethodDelete, path, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.c.do(req, nil)
+}
+
+// Publish starts publishing every entity of the release
+func (s *releasesService) Publish(ctx context.Context, spaceID, environmentID string, release *Release) (*ReleaseAction, error) {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/releases/%s/published", spaceID, environmentID, release.Sys.ID)
+
+	req, err := s.c.newRequest(ctx, http.MethodPut, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Contentful-Version", strconv.Itoa(release.Sys.Version))
+
+	var action ReleaseAction
+	if err := s.c.do(req, &action); err != nil {
+		return nil, err
+	}
+	return &action, nil
+}
+
+// Validate starts validating every entity of the release for publishing
+func (s *releasesService) Validate(ctx context.Context, spaceID, environmentID string, release *Release) (*ReleaseAction, error) {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/releases/%s/validate", spaceID, environmentID, release.Sys.ID)
+
+	req, err := s.c.newRequest(ctx, http.MethodPost, path, nil, map[string]string{"action": "publish"})
+	if err != nil {
+		return nil, err
+	}
+
+	var action ReleaseAction
+	if err := s.c.do(req, &action); err != nil {
+		return nil, err
+	}
+	return &action, nil
+}
+
+// GetAction returns a single release action
+func (s *releasesService) GetAction(ctx context.Context, spaceID, environmentID, releaseID, actionID string) (*ReleaseAction, error) {
+	path := fmt.Sprintf("/spaces/%s/environments/%s/releases/%s/actions/%s", spaceID, environmentID, releaseID, actionID)
+
+	req, err := s.c.newRequest(ctx, http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var action ReleaseAction
+	if err := s.c.do(req, &action); err != nil {
+		return nil, err
+	}
+	return &action, nil
+}
@@ -0,0 +1,79 @@
+package contentful
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	contentful "github.com/kitagry/contentful-go"
+)
+
+// ScheduledAction model
+type ScheduledAction struct {
+	Sys          *ScheduledActionSys         `json:"sys,omitempty"`
+	Entity       contentful.Entity           `json:"entity"`
+	Environment  contentful.EnvironmentLink  `json:"environment"`
+	ScheduledFor ScheduledActionScheduledFor `json:"scheduledFor"`
+	Action       string                      `json:"action"`
+}
+
+// ScheduledActionSys model
+type ScheduledActionSys struct {
+	ID      string `json:"id,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Version int    `json:"version,omitempty"`
+	Status  string `json:"status,omitempty"`
+}
+
+// ScheduledActionScheduledFor model
+type ScheduledActionScheduledFor struct {
+	Datetime string `json:"datetime"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+type scheduledActionsService struct {
+	c *cmaClient
+}
+
+// Get returns a single scheduled action
+func (s *scheduledActionsService) Get(ctx context.Context, spaceID, environmentID, scheduledActionID string) (*ScheduledAction, error) {
+	path := fmt.Sprintf("/spaces/%s/scheduled_actions/%s", spaceID, scheduledActionID)
+	query := url.Values{"environment.sys.id": []string{environmentID}}
+
+	req, err := s.c.newRequest(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var scheduledAction ScheduledAction
+	if err := s.c.do(req, &scheduledAction); err != nil {
+		return nil, err
+	}
+	return &scheduledAction, nil
+}
+
+// Create schedules a new action
+func (s *scheduledActionsService) Create(ctx context.Context, spaceID string, scheduledAction *ScheduledAction) error {
+	path := fmt.Sprintf("/spaces/%s/scheduled_actions", spaceID)
+
+	req, err := s.c.newRequest(ctx, http.MethodPost, path, nil, scheduledAction)
+	if err != nil {
+		return err
+	}
+
+	return s.c.do(req, scheduledAction)
+}
+
+// Cancel cancels the scheduled action
+func (s *scheduledActionsService) Cancel(ctx context.Context, spaceID, environmentID, scheduledActionID string) error {
+	path := fmt.Sprintf("/spaces/%s/scheduled_actions/%s", spaceID, scheduledActionID)
+	query := url.Values{"environment.sys.id": []string{environmentID}}
+
+	req, err := s.c.newRequest(ctx, http.MethodDelete, path, query, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.c.do(req, nil)
+}
@@ -0,0 +1,86 @@
+package contentful
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRateLimitTransport(t *testing.T) {
+	tests := map[string]struct {
+		reset   string
+		timeout time.Duration
+
+		expectStatus   int
+		expectRequests int
+	}{
+		"rate limited request should be retried after the reset": {
+			reset:          "0",
+			timeout:        time.Minute,
+			expectStatus:   http.StatusOK,
+			expectRequests: 2,
+		},
+		"request should not wait for a reset after its deadline": {
+			reset:          "60",
+			timeout:        time.Second,
+			expectStatus:   http.StatusTooManyRequests,
+			expectRequests: 1,
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			var requests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if body, _ := io.ReadAll(r.Body); string(body) != "payload" {
+					t.Errorf("request %d has body %q", requests, body)
+				}
+				if requests == 1 {
+					w.Header().Set("X-Contentful-RateLimit-Reset", tt.reset)
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+
+			req, _ := http.NewRequestWithContext(ctx, http.MethodPut, server.URL, io.NopCloser(strings.NewReader("payload")))
+			client := &http.Client{Transport: &rateLimitTransport{next: http.DefaultTransport}}
+			res, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			defer res.Body.Close()
+
+			if res.StatusCode != tt.expectStatus {
+				t.Errorf("status = %d, expect %d", res.StatusCode, tt.expectStatus)
+			}
+			if res.Header.Get("X-Contentful-RateLimit-Reset") != "" {
+				t.Error("reset header should be removed so that contentful-go does not sleep")
+			}
+			if requests != tt.expectRequests {
+				t.Errorf("requests = %d, expect %d", requests, tt.expectRequests)
+			}
+		})
+	}
+}
+
+func TestEnvironmentsService_GetStatus(t *testing.T) {
+	client, _ := newFakeCMAEnvironment(t)
+
+	status, err := (&environmentsService{c: newCMAClient(client)}).GetStatus(context.Background(), "space", "master")
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if status != environmentStatusReady {
+		t.Errorf("status = %s, expect %s", status, environmentStatusReady)
+	}
+}
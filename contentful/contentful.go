@@ -2,6 +2,10 @@ package contentful
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -25,12 +29,20 @@ type ContentfulAssetClient interface {
 	Unarchive(ctx context.Context, spaceID string, asset *contentful.Asset) error
 }
 
+type ContentfulBulkActionClient interface {
+	Publish(ctx context.Context, spaceID, environmentID string, entities EntityLinks) (*BulkAction, error)
+	Unpublish(ctx context.Context, spaceID, environmentID string, entities EntityLinks) (*BulkAction, error)
+	Validate(ctx context.Context, spaceID, environmentID string, entities EntityLinks) (*BulkAction, error)
+	GetAction(ctx context.Context, spaceID, environmentID, bulkActionID string) (*BulkAction, error)
+	Versions(ctx context.Context, spaceID, environmentID, linkType string, ids []string) (map[string]int, error)
+}
+
 type ContentfulContentTypeClient interface {
-	Get(ctx context.Context, env *contentful.Environment, contentTypeID string) (*contentful.ContentType, error)
-	Upsert(ctx context.Context, env *contentful.Environment, ct *contentful.ContentType) error
-	Activate(ctx context.Context, env *contentful.Environment, ct *contentful.ContentType) error
-	Deactivate(ctx context.Context, env *contentful.Environment, ct *contentful.ContentType) error
-	Delete(ctx context.Context, env *contentful.Environment, ct *contentful.ContentType) error
+	Get(ctx context.Context, env *contentful.Environment, contentTypeID string) (*ContentType, error)
+	Upsert(ctx context.Context, env *contentful.Environment, ct *ContentType) error
+	Activate(ctx context.Context, env *contentful.Environment, ct *ContentType) error
+	Deactivate(ctx context.Context, env *contentful.Environment, ct *ContentType) error
+	Delete(ctx context.Context, env *contentful.Environment, ct *ContentType) error
 }
 
 type ContentfulEntryClient interface {
@@ -44,18 +56,50 @@ type ContentfulEntryClient interface {
 	Unarchive(ctx context.Context, env *contentful.Environment, entry *contentful.Entry) error
 }
 
+type ContentfulEntryListClient interface {
+	ListByContentType(ctx context.Context, spaceID, environmentID, contentTypeID string, skip, limit int) (*EntryCollection, error)
+}
+
 type ContentfulEnvironmentClient interface {
 	Get(ctx context.Context, spaceID string, environmentID string) (*contentful.Environment, error)
 	Upsert(ctx context.Context, spaceID string, e *contentful.Environment) error
 	Delete(ctx context.Context, spaceID string, e *contentful.Environment) error
 }
 
+type ContentfulEnvironmentEntityClient interface {
+	Get(ctx context.Context, env *contentful.Environment, path string) (EntityDocument, error)
+	List(ctx context.Context, env *contentful.Environment, collection string) ([]EntityDocument, error)
+	Put(ctx context.Context, env *contentful.Environment, path string, version int, doc EntityDocument, contentTypeID string) (EntityDocument, error)
+	Post(ctx context.Context, env *contentful.Environment, collection string, doc EntityDocument) (EntityDocument, error)
+	Delete(ctx context.Context, env *contentful.Environment, path string, version int) (EntityDocument, error)
+}
+
+type ContentfulEnvironmentStatusClient interface {
+	GetStatus(ctx context.Context, spaceID string, environmentID string) (string, error)
+}
+
 type ContentfulLocaleClient interface {
 	Get(context.Context, string, string) (*contentful.Locale, error)
 	Upsert(context.Context, string, *contentful.Locale) error
 	Delete(context.Context, string, *contentful.Locale) error
 }
 
+type ContentfulReleaseClient interface {
+	Get(ctx context.Context, spaceID, environmentID, releaseID string) (*Release, error)
+	Upsert(ctx context.Context, spaceID, environmentID string, release *Release) error
+	Delete(ctx context.Context, spaceID, environmentID, releaseID string) error
+
+	Publish(ctx context.Context, spaceID, environmentID string, release *Release) (*ReleaseAction, error)
+	Validate(ctx context.Context, spaceID, environmentID string, release *Release) (*ReleaseAction, error)
+	GetAction(ctx context.Context, spaceID, environmentID, releaseID, actionID string) (*ReleaseAction, error)
+}
+
+type ContentfulScheduledActionClient interface {
+	Get(ctx context.Context, spaceID, environmentID, scheduledActionID string) (*ScheduledAction, error)
+	Create(ctx context.Context, spaceID string, scheduledAction *ScheduledAction) error
+	Cancel(ctx context.Context, spaceID, environmentID, scheduledActionID string) error
+}
+
 type ContentfulSpaceClient interface {
 	Get(context.Context, string) (*contentful.Space, error)
 	Upsert(context.Context, *contentful.Space) error
@@ -68,16 +112,31 @@ type ContentfulWebhookClient interface {
 	Delete(context.Context, string, *contentful.Webhook) error
 }
 
+// contentfulErrorToDiagnostic converts err into diagnostics with the details of the API error it wraps, if any.
+// The context of a wrapped API error, such as the entity it was returned for, is kept in the summaries.
 func contentfulErrorToDiagnostic(err error) diag.Diagnostics {
-	switch v := err.(type) {
-	case contentful.ErrorResponse:
-		return convertContentfulErrorResponse(&v)
-	case contentful.ValidationFailedError:
-		res, ok := v.ErrorResponse()
-		if ok {
-			return convertContentfulErrorResponse(res)
+	var res *contentful.ErrorResponse
+	var cause error
+	var errorResponse contentful.ErrorResponse
+	var validationFailed contentful.ValidationFailedError
+	switch {
+	case errors.As(err, &errorResponse):
+		res, cause = &errorResponse, errorResponse
+	case errors.As(err, &validationFailed):
+		if r, ok := validationFailed.ErrorResponse(); ok {
+			res, cause = r, validationFailed
 		}
 	}
+
+	if res != nil {
+		diags := convertContentfulErrorResponse(res)
+		if prefix := strings.TrimSuffix(err.Error(), cause.Error()); prefix != err.Error() {
+			for i := range diags {
+				diags[i].Summary = prefix + diags[i].Summary
+			}
+		}
+		return diags
+	}
 	return diag.Diagnostics{
 		{
 			Severity: diag.Error,
@@ -87,6 +146,15 @@ func contentfulErrorToDiagnostic(err error) diag.Diagnostics {
 }
 
 func convertContentfulErrorResponse(v *contentful.ErrorResponse) diag.Diagnostics {
+	if v.Details == nil || len(v.Details.Errors) == 0 {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  v.Message,
+			},
+		}
+	}
+
 	diags := make(diag.Diagnostics, 0)
 	for _, e := range v.Details.Errors {
 		var path cty.Path
@@ -114,3 +182,66 @@ func convertContentfulErrorResponse(v *contentful.ErrorResponse) diag.Diagnostic
 	}
 	return diags
 }
+
+// actionErrorToDiagnostic returns a diagnostic for each entity which made a release or bulk action fail.
+func actionErrorToDiagnostic(e *ActionError) diag.Diagnostics {
+	if e.Details == nil || len(e.Details.Errors) == 0 {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  e.Message,
+			},
+		}
+	}
+
+	diags := make(diag.Diagnostics, 0)
+	for _, entityErr := range e.Details.Errors {
+		entity := fmt.Sprintf("%s %s", entityErr.Entity.Sys.LinkType, entityErr.Entity.Sys.ID)
+
+		if entityErr.Error.Details == nil || len(entityErr.Error.Details.Errors) == 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("%s: %s", entity, entityErr.Error.Message),
+			})
+			continue
+		}
+
+		for _, detail := range entityErr.Error.Details.Errors {
+			// The path points into the entity, not into the Terraform configuration.
+			detailMsg := detail.Details
+			if path, ok := detail.Path.([]interface{}); ok && len(path) > 0 {
+				elems := make([]string, len(path))
+				for i, p := range path {
+					elems[i] = fmt.Sprint(p)
+				}
+				detailMsg = fmt.Sprintf("%s: %s", strings.Join(elems, "."), detailMsg)
+			}
+
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("%s: %s", entity, entityErr.Error.Message),
+				Detail:   detailMsg,
+			})
+		}
+	}
+	return diags
+}
+
+// pollUntil calls f every interval until f reports that it is done, f fails or ctx is done.
+func pollUntil(ctx context.Context, interval time.Duration, f func() (bool, error)) error {
+	for {
+		done, err := f()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package contentful
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -68,6 +70,44 @@ func TestContentfulErrorToDiagnostic(t *testing.T) {
 				},
 			},
 		},
+		"ErrorResponse without details should return its message": {
+			err: contentful.ErrorResponse{
+				Message: "msg",
+			},
+			expect: diag.Diagnostics{
+				{
+					Summary: "msg",
+				},
+			},
+		},
+		"wrapped ErrorResponse should return diagnostics with the context": {
+			err: fmt.Errorf("failed to delete entry hello: %w", contentful.ErrorResponse{
+				Message: "msg",
+				Details: &contentful.ErrorDetails{
+					Errors: []*contentful.ErrorDetail{
+						{
+							Details: "details",
+							Path:    []interface{}{"fields"},
+						},
+					},
+				},
+			}),
+			expect: diag.Diagnostics{
+				{
+					Summary:       "failed to delete entry hello: msg",
+					Detail:        "details",
+					AttributePath: cty.Path{cty.GetAttrStep{Name: "fields"}},
+				},
+			},
+		},
+		"other errors should return their message": {
+			err: errors.New("msg"),
+			expect: diag.Diagnostics{
+				{
+					Summary: "msg",
+				},
+			},
+		},
 	}
 
 	for n, tt := range tests {
@@ -79,3 +119,68 @@ func TestContentfulErrorToDiagnostic(t *testing.T) {
 		})
 	}
 }
+
+func TestActionErrorToDiagnostic(t *testing.T) {
+	tests := map[string]struct {
+		err    *ActionError
+		expect diag.Diagnostics
+	}{
+		"error without entity errors should return its message": {
+			err: &ActionError{
+				Message: "msg",
+			},
+			expect: diag.Diagnostics{
+				{
+					Summary: "msg",
+				},
+			},
+		},
+		"entity errors should return each diagnostics": {
+			err: &ActionError{
+				Message: "msg",
+				Details: &ActionErrorDetails{
+					Errors: []*EntityError{
+						{
+							Entity: contentful.Entity{Sys: contentful.Sys{Type: "Link", LinkType: "Entry", ID: "entry1"}},
+							Error: contentful.ErrorResponse{
+								Message: "Validation error",
+								Details: &contentful.ErrorDetails{
+									Errors: []*contentful.ErrorDetail{
+										{
+											Details: "The property \"title\" is required here",
+											Path:    []interface{}{"fields", "title"},
+										},
+									},
+								},
+							},
+						},
+						{
+							Entity: contentful.Entity{Sys: contentful.Sys{Type: "Link", LinkType: "Asset", ID: "asset1"}},
+							Error: contentful.ErrorResponse{
+								Message: "Not found",
+							},
+						},
+					},
+				},
+			},
+			expect: diag.Diagnostics{
+				{
+					Summary: "Entry entry1: Validation error",
+					Detail:  "fields.title: The property \"title\" is required here",
+				},
+				{
+					Summary: "Asset asset1: Not found",
+				},
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			got := actionErrorToDiagnostic(tt.err)
+			if diff := cmp.Diff(tt.expect, got); diff != "" {
+				t.Errorf("actionErrorToDiagnostic result diff (-expect, +got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,376 @@
+package contentful
+
+import (
+	"context"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+func dataSourceContentfulContentTypeTypeScript() *schema.Resource {
+	return &schema.Resource{
+		Description: "Renders TypeScript interfaces of the fields of content types, so that they can be written to a file with `local_file`",
+		ReadContext: wrapContentTypeTypes(func(d *schema.ResourceData, cts []*ContentType) (string, error) {
+			return renderTypeScript(cts)
+		}),
+		Schema: contentTypeTypesSchema("TypeScript interfaces", nil),
+	}
+}
+
+func dataSourceContentfulContentTypeGo() *schema.Resource {
+	return &schema.Resource{
+		Description: "Renders Go structs of the fields of content types, so that they can be written to a file with `local_file`",
+		ReadContext: wrapContentTypeTypes(func(d *schema.ResourceData, cts []*ContentType) (string, error) {
+			return renderGoStructs(d.Get("package_name").(string), cts)
+		}),
+		Schema: contentTypeTypesSchema("Go source file", map[string]*schema.Schema{
+			"package_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "contentful",
+				Description: "The name of the package of the Go source file",
+			},
+		}),
+	}
+}
+
+func contentTypeTypesSchema(content string, extra map[string]*schema.Schema) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"space_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The ID of the space. Defaults to `space_id` of the provider",
+		},
+		"env_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The ID of the environment. Defaults to `environment_id` of the provider",
+		},
+		"content_type_ids": {
+			Type:        schema.TypeList,
+			Required:    true,
+			MinItems:    1,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The IDs of the content types to render, in the order they are rendered",
+		},
+		"content": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The " + content + ". The types describe the value of each field in a single locale",
+		},
+	}
+	for k, v := range extra {
+		s[k] = v
+	}
+	return s
+}
+
+func wrapContentTypeTypes(render func(d *schema.ResourceData, cts []*ContentType) (string, error)) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+		meta := m.(*providerMeta)
+		if err := setDataSourceProviderDefaults(d, meta); err != nil {
+			diags = append(diags, contentfulErrorToDiagnostic(err)...)
+			return
+		}
+
+		spaceID := d.Get("space_id").(string)
+		envID := d.Get("env_id").(string)
+		env, err := meta.environments.Get(ctx, meta.client.Environments, spaceID, envID)
+		if err != nil {
+			diags = append(diags, contentfulErrorToDiagnostic(err)...)
+			return
+		}
+
+		return dataSourceContentTypeTypesRead(ctx, d, env, &contentTypesService{c: newCMAClient(meta.client)}, render)
+	}
+}
+
+func dataSourceContentTypeTypesRead(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulContentTypeClient, render func(d *schema.ResourceData, cts []*ContentType) (string, error)) (diags diag.Diagnostics) {
+	ids := toStrings(d.Get("content_type_ids").([]interface{}))
+	cts := make([]*ContentType, 0, len(ids))
+	for _, id := range ids {
+		ct, err := client.Get(ctx, env, id)
+		if err != nil {
+			diags = append(diags, contentfulErrorToDiagnostic(err)...)
+			return
+		}
+		cts = append(cts, ct)
+	}
+
+	content, err := render(d, cts)
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+	if err := d.Set("content", content); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", d.Get("space_id"), d.Get("env_id"), strings.Join(ids, ",")))
+	return
+}
+
+// typeName converts an ID such as blogPost or blog-post to BlogPost.
+func typeName(id string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range id {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	name := b.String()
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "T" + name
+	}
+	return name
+}
+
+// checkTypeNames returns an error when two content types get the same type name. When fieldNames is true, it also
+// checks that the fields of each content type get distinct field names, which the Go structs need.
+func checkTypeNames(cts []*ContentType, fieldNames bool) error {
+	typeIDs := make(map[string]string, len(cts))
+	for _, ct := range cts {
+		name := typeName(ct.Sys.ID)
+		if id, ok := typeIDs[name]; ok && id != ct.Sys.ID {
+			return fmt.Errorf("content types %s and %s both get the type name %sFields", id, ct.Sys.ID, name)
+		}
+		typeIDs[name] = ct.Sys.ID
+
+		if !fieldNames {
+			continue
+		}
+		fieldIDs := make(map[string]string, len(ct.Fields))
+		for _, field := range ct.Fields {
+			if field.Omitted {
+				continue
+			}
+			apiName := apiNameOf(field)
+			name := typeName(apiName)
+			if id, ok := fieldIDs[name]; ok {
+				return fmt.Errorf("fields %s and %s of content type %s both get the field name %s", id, apiName, ct.Sys.ID, name)
+			}
+			fieldIDs[name] = apiName
+		}
+	}
+	return nil
+}
+
+// predefinedValues returns the values of the in validation, which Symbol fields are limited to.
+func predefinedValues(validations []contentful.FieldValidation) []string {
+	for _, v := range validations {
+		if in, ok := v.(contentful.FieldValidationPredefinedValues); ok {
+			values := make([]string, 0, len(in.In))
+			for _, value := range in.In {
+				s, ok := value.(string)
+				if !ok {
+					return nil
+				}
+				values = append(values, s)
+			}
+			return values
+		}
+	}
+	return nil
+}
+
+const typeScriptLinkTypes = `export interface Link<T extends "Entry" | "Asset"> {
+  sys: { type: "Link"; linkType: T; id: string };
+}
+
+export interface ResourceLink {
+  sys: { type: "ResourceLink"; linkType: string; urn: string };
+}
+
+export interface Location {
+  lat: number;
+  lon: number;
+}
+`
+
+func renderTypeScript(cts []*ContentType) (string, error) {
+	// TypeScript merges interfaces of the same name without an error, so that the collisions are checked here.
+	if err := checkTypeNames(cts, false); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by terraform-provider-contentful. DO NOT EDIT.\n\n")
+	b.WriteString(typeScriptLinkTypes)
+
+	for _, ct := range cts {
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "/** Fields of %s */\n", ct.Name)
+		fmt.Fprintf(&b, "export interface %sFields {\n", typeName(ct.Sys.ID))
+		for _, field := range ct.Fields {
+			if field.Omitted {
+				continue
+			}
+			optional := "?"
+			if field.Required {
+				optional = ""
+			}
+			fmt.Fprintf(&b, "  %s%s: %s;\n", apiNameOf(field), optional, typeScriptType(field.Type, field.LinkType, field.Validations, field.Items))
+		}
+		b.WriteString("}\n")
+	}
+	return b.String(), nil
+}
+
+func typeScriptType(fieldType, linkType string, validations []contentful.FieldValidation, items *contentful.FieldTypeArrayItem) string {
+	switch fieldType {
+	case contentful.FieldTypeSymbol:
+		if values := predefinedValues(validations); len(values) > 0 {
+			quoted := make([]string, 0, len(values))
+			for _, v := range values {
+				quoted = append(quoted, strconv.Quote(v))
+			}
+			return strings.Join(quoted, " | ")
+		}
+		return "string"
+	case contentful.FieldTypeText, contentful.FieldTypeDate:
+		return "string"
+	case contentful.FieldTypeInteger, "Number":
+		return "number"
+	case contentful.FieldTypeBoolean:
+		return "boolean"
+	case contentful.FieldTypeLocation:
+		return "Location"
+	case contentful.FieldTypeLink:
+		return fmt.Sprintf("Link<%q>", linkType)
+	case "ResourceLink":
+		return "ResourceLink"
+	case contentful.FieldTypeArray:
+		if items == nil {
+			return "unknown[]"
+		}
+		itemType := typeScriptType(items.Type, items.LinkType, items.Validations, nil)
+		if strings.Contains(itemType, " | ") {
+			itemType = "(" + itemType + ")"
+		}
+		return itemType + "[]"
+	default:
+		// Object and RichText are free-form JSON.
+		return "Record<string, unknown>"
+	}
+}
+
+const goLinkTypes = `// EntryLink is a link to an entry.
+type EntryLink struct {
+	Sys LinkSys ` + "`json:\"sys\"`" + `
+}
+
+// AssetLink is a link to an asset.
+type AssetLink struct {
+	Sys LinkSys ` + "`json:\"sys\"`" + `
+}
+
+// LinkSys is the sys of a link.
+type LinkSys struct {
+	Type     string ` + "`json:\"type\"`" + `
+	LinkType string ` + "`json:\"linkType\"`" + `
+	ID       string ` + "`json:\"id\"`" + `
+}
+
+// ResourceLink is a link to a resource, such as an entry of another space.
+type ResourceLink struct {
+	Sys ResourceLinkSys ` + "`json:\"sys\"`" + `
+}
+
+// ResourceLinkSys is the sys of a resource link.
+type ResourceLinkSys struct {
+	Type     string ` + "`json:\"type\"`" + `
+	LinkType string ` + "`json:\"linkType\"`" + `
+	URN      string ` + "`json:\"urn\"`" + `
+}
+
+// Location is a geographic location.
+type Location struct {
+	Lat float64 ` + "`json:\"lat\"`" + `
+	Lon float64 ` + "`json:\"lon\"`" + `
+}
+`
+
+func renderGoStructs(packageName string, cts []*ContentType) (string, error) {
+	// Duplicated names are valid to format.Source, but do not compile.
+	if err := checkTypeNames(cts, true); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by terraform-provider-contentful. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", packageName)
+	b.WriteString(goLinkTypes)
+
+	for _, ct := range cts {
+		name := typeName(ct.Sys.ID) + "Fields"
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "// %s are the fields of %s.\n", name, ct.Name)
+		fmt.Fprintf(&b, "type %s struct {\n", name)
+		for _, field := range ct.Fields {
+			if field.Omitted {
+				continue
+			}
+			apiName := apiNameOf(field)
+			typ := goType(field.Type, field.LinkType, field.Items)
+			tag := apiName
+			if !field.Required {
+				tag += ",omitempty"
+				if !strings.HasPrefix(typ, "[]") && !strings.HasPrefix(typ, "map[") {
+					typ = "*" + typ
+				}
+			}
+			fmt.Fprintf(&b, "\t%s %s `json:%q`\n", typeName(apiName), typ, tag)
+		}
+		b.WriteString("}\n")
+	}
+
+	src, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return "", fmt.Errorf("failed to format the Go source: %w", err)
+	}
+	return string(src), nil
+}
+
+func goType(fieldType, linkType string, items *contentful.FieldTypeArrayItem) string {
+	switch fieldType {
+	case contentful.FieldTypeSymbol, contentful.FieldTypeText, contentful.FieldTypeDate:
+		return "string"
+	case contentful.FieldTypeInteger:
+		return "int64"
+	case "Number":
+		return "float64"
+	case contentful.FieldTypeBoolean:
+		return "bool"
+	case contentful.FieldTypeLocation:
+		return "Location"
+	case contentful.FieldTypeLink:
+		// Like Link<"Entry"> and Link<"Asset"> of the TypeScript interfaces.
+		return linkType + "Link"
+	case "ResourceLink":
+		return "ResourceLink"
+	case contentful.FieldTypeArray:
+		if items == nil {
+			return "[]interface{}"
+		}
+		return "[]" + goType(items.Type, items.LinkType, nil)
+	default:
+		// Object and RichText are free-form JSON.
+		return "map[string]interface{}"
+	}
+}
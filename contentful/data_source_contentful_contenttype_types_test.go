@@ -0,0 +1,111 @@
+package contentful
+
+import (
+	"strings"
+	"testing"
+
+	contentful "github.com/kitagry/contentful-go"
+)
+
+func testTypesContentType() *ContentType {
+	return &ContentType{
+		Sys:  &contentful.Sys{ID: "blogPost"},
+		Name: "Blog Post",
+		Fields: []*ContentTypeField{
+			{Field: contentful.Field{ID: "title", Type: "Symbol", Required: true}, APIName: "headline"},
+			{Field: contentful.Field{ID: "category", Type: "Symbol", Validations: []contentful.FieldValidation{
+				contentful.FieldValidationPredefinedValues{In: []interface{}{"news", "tips"}},
+			}}},
+			{Field: contentful.Field{ID: "tags", Type: "Array", Items: &contentful.FieldTypeArrayItem{Type: "Symbol"}}},
+			{Field: contentful.Field{ID: "author", Type: "Link", LinkType: "Entry", Required: true}},
+			{Field: contentful.Field{ID: "views", Type: "Integer"}},
+			{Field: contentful.Field{ID: "legacy", Type: "Text", Omitted: true}},
+		},
+	}
+}
+
+func TestRenderTypeScript(t *testing.T) {
+	expect := `/** Fields of Blog Post */
+export interface BlogPostFields {
+  headline: string;
+  category?: "news" | "tips";
+  tags?: string[];
+  author: Link<"Entry">;
+  views?: number;
+}
+`
+	got, err := renderTypeScript([]*ContentType{testTypesContentType()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(got, expect) {
+		t.Errorf("renderTypeScript() = %s, should end with %s", got, expect)
+	}
+}
+
+func TestRenderGoStructs(t *testing.T) {
+	expect := "// BlogPostFields are the fields of Blog Post.\n" +
+		"type BlogPostFields struct {\n" +
+		"\tHeadline string    `json:\"headline\"`\n" +
+		"\tCategory *string   `json:\"category,omitempty\"`\n" +
+		"\tTags     []string  `json:\"tags,omitempty\"`\n" +
+		"\tAuthor   EntryLink `json:\"author\"`\n" +
+		"\tViews    *int64    `json:\"views,omitempty\"`\n" +
+		"}\n"
+	got, err := renderGoStructs("models", []*ContentType{testTypesContentType()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, "\npackage models\n") {
+		t.Errorf("renderGoStructs() = %s, should be in package models", got)
+	}
+	if !strings.HasSuffix(got, expect) {
+		t.Errorf("renderGoStructs() = %s, should end with %s", got, expect)
+	}
+}
+
+func TestRenderTypesNameCollision(t *testing.T) {
+	tests := map[string]struct {
+		cts []*ContentType
+
+		expectTypeScriptErr bool
+		expectGoErr         bool
+	}{
+		"content types with the same type name should fail": {
+			cts: []*ContentType{
+				{Sys: &contentful.Sys{ID: "blog-post"}, Name: "Blog Post"},
+				{Sys: &contentful.Sys{ID: "blog_post"}, Name: "Blog Post 2"},
+			},
+			expectTypeScriptErr: true,
+			expectGoErr:         true,
+		},
+		"fields with the same Go field name should fail only in Go": {
+			cts: []*ContentType{
+				{Sys: &contentful.Sys{ID: "blogPost"}, Name: "Blog Post", Fields: []*ContentTypeField{
+					{Field: contentful.Field{ID: "title", Type: "Symbol"}},
+					{Field: contentful.Field{ID: "Title", Type: "Symbol"}},
+				}},
+			},
+			expectGoErr: true,
+		},
+		"omitted fields should not collide": {
+			cts: []*ContentType{
+				{Sys: &contentful.Sys{ID: "blogPost"}, Name: "Blog Post", Fields: []*ContentTypeField{
+					{Field: contentful.Field{ID: "title", Type: "Symbol"}},
+					{Field: contentful.Field{ID: "Title", Type: "Symbol", Omitted: true}},
+				}},
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			if _, err := renderTypeScript(tt.cts); (err != nil) != tt.expectTypeScriptErr {
+				t.Errorf("renderTypeScript() error = %v, expect error %t", err, tt.expectTypeScriptErr)
+			}
+			if _, err := renderGoStructs("models", tt.cts); (err != nil) != tt.expectGoErr {
+				t.Errorf("renderGoStructs() error = %v, expect error %t", err, tt.expectGoErr)
+			}
+		})
+	}
+}
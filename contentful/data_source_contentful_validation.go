@@ -0,0 +1,218 @@
+package contentful
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+// validationKinds are the attributes of contentful_validation. Each validation has exactly one of them.
+var validationKinds = []string{"size", "range", "regexp", "link_content_type", "link_mimetype_group", "in", "in_numbers", "unique"}
+
+func dataSourceContentfulValidation() *schema.Resource {
+	minMax := func(what string, typ schema.ValueType) *schema.Resource {
+		return &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"min": {
+					Type:        typ,
+					Optional:    true,
+					Description: "The minimum " + what,
+				},
+				"max": {
+					Type:        typ,
+					Optional:    true,
+					Description: "The maximum " + what,
+				},
+			},
+		}
+	}
+
+	return &schema.Resource{
+		Description: "Builds the JSON of a field validation for `validations` of `contentful_contenttype`. Exactly one kind of validation is set",
+		ReadContext: dataSourceValidationRead,
+
+		Schema: map[string]*schema.Schema{
+			"size": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				Elem:         minMax("length of a text, or number of items of an array", schema.TypeInt),
+				ExactlyOneOf: validationKinds,
+				Description:  "Limits the length of a text, or the number of items of an array",
+			},
+			"range": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				Elem:         minMax("value", schema.TypeFloat),
+				ExactlyOneOf: validationKinds,
+				Description:  "Limits the value of a number",
+			},
+			"regexp": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"pattern": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The regular expression the text must match",
+						},
+						"flags": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The flags of the regular expression, such as `i`",
+						},
+					},
+				},
+				ExactlyOneOf: validationKinds,
+				Description:  "Requires a text to match a regular expression",
+			},
+			"link_content_type": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ExactlyOneOf: validationKinds,
+				Description:  "The IDs of the content types which linked entries must be of",
+			},
+			"link_mimetype_group": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ExactlyOneOf: validationKinds,
+				Description:  "The MIME type groups which linked assets must be of, such as `image` or `pdfdocument`",
+			},
+			"in": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ExactlyOneOf: validationKinds,
+				Description:  "The values which a `Symbol` or `Text` field may have. Use `in_numbers` for `Integer` and `Number` fields",
+			},
+			"in_numbers": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeFloat},
+				ExactlyOneOf: validationKinds,
+				Description:  "The values which an `Integer` or `Number` field may have",
+			},
+			"unique": {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				ExactlyOneOf: validationKinds,
+				Description:  "Whether the value must be unique among the entries of the content type",
+			},
+			"message": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The message shown when the validation fails. Not used by `link_content_type`, `link_mimetype_group` and `unique`",
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The JSON of the validation, to be used in `validations`",
+			},
+		},
+	}
+}
+
+func dataSourceValidationRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+	validation := newFieldValidation(d)
+
+	b, err := json.Marshal(validation)
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	if err := d.Set("json", string(b)); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+	d.SetId(strconv.Itoa(schema.HashString(string(b))))
+	return
+}
+
+// validationMinMax is the bounds of a size or range validation. Unlike contentful.MinMax, it keeps a bound of 0.
+type validationMinMax struct {
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+}
+
+type fieldValidationSize struct {
+	Size         *validationMinMax `json:"size"`
+	ErrorMessage string            `json:"message,omitempty"`
+}
+
+type fieldValidationRange struct {
+	Range        *validationMinMax `json:"range"`
+	ErrorMessage string            `json:"message,omitempty"`
+}
+
+// fieldValidationIn is the predefined values of a validation. Unlike contentful.FieldValidationPredefinedValues,
+// it leaves out an empty message.
+type fieldValidationIn struct {
+	In           []interface{} `json:"in"`
+	ErrorMessage string        `json:"message,omitempty"`
+}
+
+// newFieldValidation builds the validation with the models of contentful-go, so that the JSON is parsed back
+// by contentful.ParseValidations into the same validation. Size, range and in have their own models to keep bounds
+// of 0 and to leave out an empty message.
+func newFieldValidation(d *schema.ResourceData) contentful.FieldValidation {
+	message := d.Get("message").(string)
+
+	if v, ok := d.GetOk("size"); ok && len(v.([]interface{})) > 0 {
+		return fieldValidationSize{Size: expandValidationMinMax(d, "size"), ErrorMessage: message}
+	}
+	if v, ok := d.GetOk("range"); ok && len(v.([]interface{})) > 0 {
+		return fieldValidationRange{Range: expandValidationMinMax(d, "range"), ErrorMessage: message}
+	}
+	if v, ok := d.GetOk("regexp.0"); ok {
+		regexp := v.(map[string]interface{})
+		return contentful.FieldValidationRegex{
+			Regex:        &contentful.Regex{Pattern: regexp["pattern"].(string), Flags: regexp["flags"].(string)},
+			ErrorMessage: message,
+		}
+	}
+	if v, ok := d.GetOk("link_content_type"); ok {
+		return contentful.FieldValidationLink{LinkContentType: toStrings(v.([]interface{}))}
+	}
+	if v, ok := d.GetOk("link_mimetype_group"); ok {
+		return contentful.FieldValidationMimeType{MimeTypes: toStrings(v.([]interface{}))}
+	}
+	if v, ok := d.GetOk("in"); ok {
+		return fieldValidationIn{In: v.([]interface{}), ErrorMessage: message}
+	}
+	if v, ok := d.GetOk("in_numbers"); ok {
+		// The values are numbers in the JSON, which Integer and Number fields require.
+		return fieldValidationIn{In: v.([]interface{}), ErrorMessage: message}
+	}
+	return contentful.FieldValidationUnique{Unique: d.Get("unique").(bool)}
+}
+
+// expandValidationMinMax returns the bounds set in the block key. GetOkExists tells a bound of 0 from an unset
+// bound, which GetOk does not.
+func expandValidationMinMax(d *schema.ResourceData, key string) *validationMinMax {
+	bounds := &validationMinMax{}
+	for name, bound := range map[string]**float64{"min": &bounds.Min, "max": &bounds.Max} {
+		v, ok := d.GetOkExists(key + ".0." + name)
+		if !ok {
+			continue
+		}
+		var f float64
+		switch n := v.(type) {
+		case int:
+			f = float64(n)
+		case float64:
+			f = n
+		}
+		*bound = &f
+	}
+	return bounds
+}
@@ -0,0 +1,125 @@
+package contentful
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+func TestDataSourceValidationRead(t *testing.T) {
+	tests := map[string]struct {
+		raw map[string]interface{}
+
+		expectJSON        string
+		expectValidations []contentful.FieldValidation
+	}{
+		"size": {
+			raw: map[string]interface{}{
+				"size":    []interface{}{map[string]interface{}{"min": 1, "max": 20}},
+				"message": "1 to 20 characters",
+			},
+			expectJSON: `{"size":{"min":1,"max":20},"message":"1 to 20 characters"}`,
+			expectValidations: []contentful.FieldValidation{
+				contentful.FieldValidationSize{Size: &contentful.MinMax{Min: 1, Max: 20}, ErrorMessage: "1 to 20 characters"},
+			},
+		},
+		"range without min": {
+			raw: map[string]interface{}{
+				"range": []interface{}{map[string]interface{}{"max": 0.5}},
+			},
+			expectJSON: `{"range":{"max":0.5}}`,
+			expectValidations: []contentful.FieldValidation{
+				contentful.FieldValidationRange{Range: &contentful.MinMax{Max: 0.5}},
+			},
+		},
+		"size with min 0": {
+			raw: map[string]interface{}{
+				"size": []interface{}{map[string]interface{}{"min": 0}},
+			},
+			expectJSON: `{"size":{"min":0}}`,
+			expectValidations: []contentful.FieldValidation{
+				contentful.FieldValidationSize{Size: &contentful.MinMax{}},
+			},
+		},
+		"range with min 0": {
+			raw: map[string]interface{}{
+				"range": []interface{}{map[string]interface{}{"min": 0.0, "max": 100.0}},
+			},
+			expectJSON: `{"range":{"min":0,"max":100}}`,
+			expectValidations: []contentful.FieldValidation{
+				contentful.FieldValidationRange{Range: &contentful.MinMax{Max: 100}},
+			},
+		},
+		"regexp": {
+			raw: map[string]interface{}{
+				"regexp": []interface{}{map[string]interface{}{"pattern": `^\d+$`, "flags": "i"}},
+			},
+			expectJSON: `{"regexp":{"pattern":"^\\d+$","flags":"i"}}`,
+			expectValidations: []contentful.FieldValidation{
+				contentful.FieldValidationRegex{Regex: &contentful.Regex{Pattern: `^\d+$`, Flags: "i"}},
+			},
+		},
+		"link content type": {
+			raw: map[string]interface{}{
+				"link_content_type": []interface{}{"post", "page"},
+			},
+			expectJSON: `{"linkContentType":["post","page"]}`,
+			expectValidations: []contentful.FieldValidation{
+				contentful.FieldValidationLink{LinkContentType: []string{"post", "page"}},
+			},
+		},
+		"in": {
+			raw: map[string]interface{}{
+				"in": []interface{}{"news", "tips"},
+			},
+			expectJSON: `{"in":["news","tips"]}`,
+			expectValidations: []contentful.FieldValidation{
+				contentful.FieldValidationPredefinedValues{In: []interface{}{"news", "tips"}},
+			},
+		},
+		"in numbers": {
+			raw: map[string]interface{}{
+				"in_numbers": []interface{}{1.0, 2.5},
+				"message":    "1 or 2.5",
+			},
+			expectJSON: `{"in":[1,2.5],"message":"1 or 2.5"}`,
+			expectValidations: []contentful.FieldValidation{
+				contentful.FieldValidationPredefinedValues{In: []interface{}{1.0, 2.5}, ErrorMessage: "1 or 2.5"},
+			},
+		},
+		"unique": {
+			raw: map[string]interface{}{
+				"unique": true,
+			},
+			expectJSON: `{"unique":true}`,
+			expectValidations: []contentful.FieldValidation{
+				contentful.FieldValidationUnique{Unique: true},
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceContentfulValidation().Schema, tt.raw)
+			if diags := dataSourceValidationRead(context.Background(), d, nil); diags.HasError() {
+				t.Fatalf("dataSourceValidationRead() diags = %v", diags)
+			}
+
+			got := d.Get("json").(string)
+			if got != tt.expectJSON {
+				t.Errorf("json = %s, expect %s", got, tt.expectJSON)
+			}
+
+			validations, err := contentful.ParseValidations([]interface{}{got})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.expectValidations, validations); diff != "" {
+				t.Errorf("parsed validations diff (-expect, +got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package contentful
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+// Publication states of entries and assets, as shown in the Contentful web app.
+const (
+	entityStatusDraft     = "draft"
+	entityStatusPublished = "published"
+	entityStatusChanged   = "changed"
+	entityStatusArchived  = "archived"
+)
+
+// entityStatus derives the publication state of an entry or asset from its sys properties.
+// Publishing increments the version, so an entity is up to date when its version is right after the published one.
+func entityStatus(sys *contentful.Sys) string {
+	switch {
+	case sys.ArchivedAt != "":
+		return entityStatusArchived
+	case sys.PublishedVersion == 0:
+		return entityStatusDraft
+	case sys.Version == sys.PublishedVersion+1:
+		return entityStatusPublished
+	default:
+		return entityStatusChanged
+	}
+}
+
+// hasPublishedVersion returns whether the entity is published, even if it changed after the last publish.
+func hasPublishedVersion(sys *contentful.Sys) bool {
+	return sys.ArchivedAt == "" && sys.PublishedVersion != 0
+}
+
+// planRepublish plans to publish an entry or asset again when it is configured as published and changed after
+// the last publish, which the published attribute alone does not show.
+func planRepublish(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.NewValueKnown("published") || !d.Get("published").(bool) {
+		return nil
+	}
+	if d.Get("status").(string) != entityStatusChanged {
+		return nil
+	}
+	if err := d.SetNewComputed("status"); err != nil {
+		return err
+	}
+	return d.SetNewComputed("published_version")
+}
+
+// Calls which move an entry or asset from one publication state to another.
+const (
+	entityTransitionPublish   = "publish"
+	entityTransitionUnpublish = "unpublish"
+	entityTransitionArchive   = "archive"
+	entityTransitionUnarchive = "unarchive"
+)
+
+// entityStateClient changes the publication state of a single entry or asset.
+// Get refreshes the entity, so that each call is issued with the latest version.
+type entityStateClient interface {
+	Get(ctx context.Context) (*contentful.Sys, error)
+	Publish(ctx context.Context) error
+	Unpublish(ctx context.Context) error
+	Archive(ctx context.Context) error
+	Unarchive(ctx context.Context) error
+}
+
+// entityTransitions returns the calls to move an entity from the current to the desired state.
+// Contentful only archives unpublished entities and only publishes unarchived ones.
+func entityTransitions(current, desired string) []string {
+	switch desired {
+	case entityStatusDraft:
+		switch current {
+		case entityStatusPublished, entityStatusChanged:
+			return []string{entityTransitionUnpublish}
+		case entityStatusArchived:
+			return []string{entityTransitionUnarchive}
+		}
+	case entityStatusPublished:
+		switch current {
+		case entityStatusDraft, entityStatusChanged:
+			return []string{entityTransitionPublish}
+		case entityStatusArchived:
+			return []string{entityTransitionUnarchive, entityTransitionPublish}
+		}
+	case entityStatusArchived:
+		switch current {
+		case entityStatusDraft:
+			return []string{entityTransitionArchive}
+		case entityStatusPublished, entityStatusChanged:
+			return []string{entityTransitionUnpublish, entityTransitionArchive}
+		}
+	}
+	return nil
+}
+
+// changeEntityState moves the entity to the state described by published and archived.
+// It stops at the first failing call, because every following call depends on it.
+func changeEntityState(ctx context.Context, client entityStateClient, published, archived bool) (diags diag.Diagnostics) {
+	if published && archived {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "published and archived cannot be true at the same time",
+			Detail:        "Contentful can only archive unpublished entries and assets.",
+			AttributePath: cty.Path{cty.GetAttrStep{Name: "archived"}},
+		})
+		return
+	}
+
+	desired := entityStatusDraft
+	if published {
+		desired = entityStatusPublished
+	} else if archived {
+		desired = entityStatusArchived
+	}
+
+	sys, err := client.Get(ctx)
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	for _, transition := range entityTransitions(entityStatus(sys), desired) {
+		switch transition {
+		case entityTransitionPublish:
+			err = client.Publish(ctx)
+		case entityTransitionUnpublish:
+			err = client.Unpublish(ctx)
+		case entityTransitionArchive:
+			err = client.Archive(ctx)
+		case entityTransitionUnarchive:
+			err = client.Unarchive(ctx)
+		}
+		if err != nil {
+			for _, d := range contentfulErrorToDiagnostic(err) {
+				d.Summary = fmt.Sprintf("failed to %s: %s", transition, d.Summary)
+				diags = append(diags, d)
+			}
+			return
+		}
+
+		if _, err = client.Get(ctx); err != nil {
+			diags = append(diags, contentfulErrorToDiagnostic(err)...)
+			return
+		}
+	}
+	return
+}
+
+// entryStateClient is the entityStateClient of an entry.
+type entryStateClient struct {
+	client ContentfulEntryClient
+	env    *contentful.Environment
+	id     string
+	entry  *contentful.Entry
+}
+
+func (c *entryStateClient) Get(ctx context.Context) (*contentful.Sys, error) {
+	entry, err := c.client.Get(ctx, c.env, c.id)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("entry %s can not be found", c.id)
+	}
+	c.entry = entry
+	return entry.Sys, nil
+}
+
+func (c *entryStateClient) Publish(ctx context.Context) error {
+	return c.client.Publish(ctx, c.env, c.entry)
+}
+
+func (c *entryStateClient) Unpublish(ctx context.Context) error {
+	return c.client.Unpublish(ctx, c.env, c.entry)
+}
+
+func (c *entryStateClient) Archive(ctx context.Context) error {
+	return c.client.Archive(ctx, c.env, c.entry)
+}
+
+func (c *entryStateClient) Unarchive(ctx context.Context) error {
+	return c.client.Unarchive(ctx, c.env, c.entry)
+}
+
+// assetStateClient is the entityStateClient of an asset.
+type assetStateClient struct {
+	client  ContentfulAssetClient
+	spaceID string
+	id      string
+	asset   *contentful.Asset
+}
+
+func (c *assetStateClient) Get(ctx context.Context) (*contentful.Sys, error) {
+	asset, err := c.client.Get(ctx, c.spaceID, c.id)
+	if err != nil {
+		return nil, err
+	}
+	c.asset = asset
+	return asset.Sys, nil
+}
+
+func (c *assetStateClient) Publish(ctx context.Context) error {
+	return c.client.Publish(ctx, c.spaceID, c.asset)
+}
+
+func (c *assetStateClient) Unpublish(ctx context.Context) error {
+	return c.client.Unpublish(ctx, c.spaceID, c.asset)
+}
+
+func (c *assetStateClient) Archive(ctx context.Context) error {
+	return c.client.Archive(ctx, c.spaceID, c.asset)
+}
+
+func (c *assetStateClient) Unarchive(ctx context.Context) error {
+	return c.client.Unarchive(ctx, c.spaceID, c.asset)
+}
@@ -0,0 +1,301 @@
+package contentful
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+func TestEntityStatus(t *testing.T) {
+	tests := map[string]struct {
+		sys *contentful.Sys
+
+		expect string
+	}{
+		"never published entity should be draft": {
+			sys:    &contentful.Sys{Version: 3},
+			expect: entityStatusDraft,
+		},
+		"entity right after publishing should be published": {
+			sys:    &contentful.Sys{Version: 4, PublishedVersion: 3, PublishedAt: "2022-01-01T00:00:00Z"},
+			expect: entityStatusPublished,
+		},
+		"entity updated after publishing should be changed": {
+			sys:    &contentful.Sys{Version: 6, PublishedVersion: 3, PublishedAt: "2022-01-01T00:00:00Z"},
+			expect: entityStatusChanged,
+		},
+		"archived entity should be archived": {
+			sys:    &contentful.Sys{Version: 5, ArchivedVersion: 4, ArchivedAt: "2022-01-01T00:00:00Z"},
+			expect: entityStatusArchived,
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			got := entityStatus(tt.sys)
+			if got != tt.expect {
+				t.Errorf("entityStatus() = %s, expect %s", got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestEntityTransitions(t *testing.T) {
+	tests := map[string]struct {
+		current string
+		desired string
+
+		expect []string
+	}{
+		"draft to published": {
+			current: entityStatusDraft,
+			desired: entityStatusPublished,
+			expect:  []string{entityTransitionPublish},
+		},
+		"changed to published should publish again": {
+			current: entityStatusChanged,
+			desired: entityStatusPublished,
+			expect:  []string{entityTransitionPublish},
+		},
+		"published to archived should unpublish first": {
+			current: entityStatusPublished,
+			desired: entityStatusArchived,
+			expect:  []string{entityTransitionUnpublish, entityTransitionArchive},
+		},
+		"archived to published should unarchive first": {
+			current: entityStatusArchived,
+			desired: entityStatusPublished,
+			expect:  []string{entityTransitionUnarchive, entityTransitionPublish},
+		},
+		"archived to draft": {
+			current: entityStatusArchived,
+			desired: entityStatusDraft,
+			expect:  []string{entityTransitionUnarchive},
+		},
+		"same state should do nothing": {
+			current: entityStatusPublished,
+			desired: entityStatusPublished,
+			expect:  nil,
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			got := entityTransitions(tt.current, tt.desired)
+			if diff := cmp.Diff(tt.expect, got); diff != "" {
+				t.Errorf("entityTransitions result diff (-expect, +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+// fakeEntityStateClient behaves like the Contentful API for publishing and archiving.
+type fakeEntityStateClient struct {
+	sys   contentful.Sys
+	calls []string
+	fail  string
+}
+
+func (c *fakeEntityStateClient) Get(ctx context.Context) (*contentful.Sys, error) {
+	sys := c.sys
+	return &sys, nil
+}
+
+func (c *fakeEntityStateClient) Publish(ctx context.Context) error {
+	if err := c.call(entityTransitionPublish); err != nil {
+		return err
+	}
+	if c.sys.ArchivedAt != "" {
+		return errors.New("cannot publish archived entity")
+	}
+	c.sys.PublishedVersion = c.sys.Version
+	c.sys.PublishedAt = "2022-01-01T00:00:00Z"
+	c.sys.Version++
+	return nil
+}
+
+func (c *fakeEntityStateClient) Unpublish(ctx context.Context) error {
+	if err := c.call(entityTransitionUnpublish); err != nil {
+		return err
+	}
+	c.sys.PublishedVersion = 0
+	c.sys.PublishedAt = ""
+	c.sys.Version++
+	return nil
+}
+
+func (c *fakeEntityStateClient) Archive(ctx context.Context) error {
+	if err := c.call(entityTransitionArchive); err != nil {
+		return err
+	}
+	if c.sys.PublishedAt != "" {
+		return errors.New("cannot archive published entity")
+	}
+	c.sys.ArchivedVersion = c.sys.Version
+	c.sys.ArchivedAt = "2022-01-01T00:00:00Z"
+	c.sys.Version++
+	return nil
+}
+
+func (c *fakeEntityStateClient) Unarchive(ctx context.Context) error {
+	if err := c.call(entityTransitionUnarchive); err != nil {
+		return err
+	}
+	c.sys.ArchivedVersion = 0
+	c.sys.ArchivedAt = ""
+	c.sys.Version++
+	return nil
+}
+
+func (c *fakeEntityStateClient) call(transition string) error {
+	c.calls = append(c.calls, transition)
+	if c.fail == transition {
+		return errors.New("failed")
+	}
+	return nil
+}
+
+func TestChangeEntityState(t *testing.T) {
+	tests := map[string]struct {
+		client    *fakeEntityStateClient
+		published bool
+		archived  bool
+
+		expectStatus string
+		expectCalls  []string
+		expectDiags  diag.Diagnostics
+	}{
+		"published entity should be archived": {
+			client:       &fakeEntityStateClient{sys: contentful.Sys{Version: 2, PublishedVersion: 1, PublishedAt: "2022-01-01T00:00:00Z"}},
+			archived:     true,
+			expectStatus: entityStatusArchived,
+			expectCalls:  []string{entityTransitionUnpublish, entityTransitionArchive},
+		},
+		"archived entity should be published": {
+			client:       &fakeEntityStateClient{sys: contentful.Sys{Version: 2, ArchivedVersion: 1, ArchivedAt: "2022-01-01T00:00:00Z"}},
+			published:    true,
+			expectStatus: entityStatusPublished,
+			expectCalls:  []string{entityTransitionUnarchive, entityTransitionPublish},
+		},
+		"failed call should stop the transition": {
+			client:       &fakeEntityStateClient{sys: contentful.Sys{Version: 2, PublishedVersion: 1, PublishedAt: "2022-01-01T00:00:00Z"}, fail: entityTransitionUnpublish},
+			archived:     true,
+			expectStatus: entityStatusPublished,
+			expectCalls:  []string{entityTransitionUnpublish},
+			expectDiags: diag.Diagnostics{
+				{
+					Summary: "failed to unpublish: failed",
+				},
+			},
+		},
+		"published and archived entity should be rejected": {
+			client:       &fakeEntityStateClient{sys: contentful.Sys{Version: 1}},
+			published:    true,
+			archived:     true,
+			expectStatus: entityStatusDraft,
+			expectDiags: diag.Diagnostics{
+				{
+					Summary:       "published and archived cannot be true at the same time",
+					Detail:        "Contentful can only archive unpublished entries and assets.",
+					AttributePath: cty.Path{cty.GetAttrStep{Name: "archived"}},
+				},
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			gotDiags := changeEntityState(context.Background(), tt.client, tt.published, tt.archived)
+			if diff := cmp.Diff(tt.expectDiags, gotDiags, cmp.AllowUnexported(cty.GetAttrStep{})); diff != "" {
+				t.Errorf("changeEntityState diags diff (-expect, +got)\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.expectCalls, tt.client.calls); diff != "" {
+				t.Errorf("changeEntityState calls diff (-expect, +got)\n%s", diff)
+			}
+			if got := entityStatus(&tt.client.sys); got != tt.expectStatus {
+				t.Errorf("entityStatus() = %s, expect %s", got, tt.expectStatus)
+			}
+		})
+	}
+}
+
+func TestPlanRepublish(t *testing.T) {
+	tests := map[string]struct {
+		published       bool
+		status          string
+		configPublished bool
+
+		expectKeys []string
+	}{
+		"changed entry configured as published should be published again": {
+			published:       true,
+			status:          entityStatusChanged,
+			configPublished: true,
+			expectKeys:      []string{"published_version", "status"},
+		},
+		"changed entry configured as unpublished should be unpublished": {
+			published:       true,
+			status:          entityStatusChanged,
+			configPublished: false,
+			expectKeys:      []string{"published"},
+		},
+		"published entry should have no diff": {
+			published:       true,
+			status:          entityStatusPublished,
+			configPublished: true,
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			r := resourceContentfulEntry()
+			state := &terraform.InstanceState{
+				ID: "hello",
+				Attributes: map[string]string{
+					"id":                "hello",
+					"space_id":          "space",
+					"env_id":            "master",
+					"entry_id":          "hello",
+					"contenttype_id":    "post",
+					"locale":            "en-US",
+					"published":         strconv.FormatBool(tt.published),
+					"archived":          "false",
+					"published_version": "3",
+					"status":            tt.status,
+					"field.#":           "0",
+				},
+			}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"space_id":       "space",
+				"env_id":         "master",
+				"entry_id":       "hello",
+				"contenttype_id": "post",
+				"locale":         "en-US",
+				"published":      tt.configPublished,
+				"archived":       false,
+			})
+			diff, err := r.SimpleDiff(context.Background(), state, config, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotKeys []string
+			if diff != nil {
+				for k := range diff.Attributes {
+					gotKeys = append(gotKeys, k)
+				}
+			}
+			sort.Strings(gotKeys)
+			if diff := cmp.Diff(tt.expectKeys, gotKeys); diff != "" {
+				t.Errorf("planned attributes diff (-expect, +got)\n%s", diff)
+			}
+		})
+	}
+}
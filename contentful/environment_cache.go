@@ -0,0 +1,100 @@
+package contentful
+
+import (
+	"context"
+	"sync"
+
+	contentful "github.com/kitagry/contentful-go"
+)
+
+type environmentCacheKey struct {
+	spaceID       string
+	environmentID string
+}
+
+// environmentCacheEntry is a lookup of an environment. done is closed once env or err is set.
+type environmentCacheEntry struct {
+	done chan struct{}
+	env  *contentful.Environment
+	err  error
+}
+
+// environmentCache shares environments among the resources of a provider, so that a refresh of many
+// entries or content types in the same environment gets the environment only once.
+// Concurrent lookups of the same environment wait for a single request. Failed lookups are not cached.
+type environmentCache struct {
+	mu      sync.Mutex
+	entries map[environmentCacheKey]*environmentCacheEntry
+}
+
+func newEnvironmentCache() *environmentCache {
+	return &environmentCache{entries: map[environmentCacheKey]*environmentCacheEntry{}}
+}
+
+// Get returns the environment from the cache, or gets it with client.
+func (c *environmentCache) Get(ctx context.Context, client ContentfulEnvironmentClient, spaceID, environmentID string) (*contentful.Environment, error) {
+	key := environmentCacheKey{spaceID: spaceID, environmentID: environmentID}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &environmentCacheEntry{done: make(chan struct{})}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		entry.env, entry.err = client.Get(ctx, spaceID, environmentID)
+		if entry.err != nil {
+			c.remove(key, entry)
+		}
+		close(entry.done)
+	}
+
+	select {
+	case <-entry.done:
+		return entry.env, entry.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Invalidate drops the environment, so that the next Get fetches it again.
+func (c *environmentCache) Invalidate(spaceID, environmentID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, environmentCacheKey{spaceID: spaceID, environmentID: environmentID})
+}
+
+// remove drops entry unless it has already been replaced by another lookup.
+func (c *environmentCache) remove(key environmentCacheKey, entry *environmentCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries[key] == entry {
+		delete(c.entries, key)
+	}
+}
+
+// cachingEnvironmentClient invalidates the cached environment when it is changed or deleted.
+type cachingEnvironmentClient struct {
+	ContentfulEnvironmentClient
+	cache *environmentCache
+}
+
+func (c *cachingEnvironmentClient) Upsert(ctx context.Context, spaceID string, e *contentful.Environment) error {
+	defer c.cache.Invalidate(spaceID, environmentIDOf(e))
+	return c.ContentfulEnvironmentClient.Upsert(ctx, spaceID, e)
+}
+
+func (c *cachingEnvironmentClient) Delete(ctx context.Context, spaceID string, e *contentful.Environment) error {
+	defer c.cache.Invalidate(spaceID, environmentIDOf(e))
+	return c.ContentfulEnvironmentClient.Delete(ctx, spaceID, e)
+}
+
+// environmentIDOf returns the ID of e. An environment is created with its name as ID.
+func environmentIDOf(e *contentful.Environment) string {
+	if e.Sys != nil && e.Sys.ID != "" {
+		return e.Sys.ID
+	}
+	return e.Name
+}
@@ -0,0 +1,95 @@
+package contentful
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	contentful "github.com/kitagry/contentful-go"
+)
+
+type countingEnvironmentClient struct {
+	ContentfulEnvironmentClient
+	gets int32
+	err  error
+}
+
+func (c *countingEnvironmentClient) Get(ctx context.Context, spaceID, environmentID string) (*contentful.Environment, error) {
+	atomic.AddInt32(&c.gets, 1)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &contentful.Environment{Sys: &contentful.Sys{ID: environmentID}, Name: environmentID}, nil
+}
+
+func (c *countingEnvironmentClient) Upsert(ctx context.Context, spaceID string, e *contentful.Environment) error {
+	return nil
+}
+
+func TestEnvironmentCache_Get(t *testing.T) {
+	ctx := context.Background()
+	cache := newEnvironmentCache()
+	client := &countingEnvironmentClient{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			env, err := cache.Get(ctx, client, "space", "master")
+			if err != nil || env.Sys.ID != "master" {
+				t.Errorf("Get() = %v, %v", env, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if client.gets != 1 {
+		t.Errorf("environment should be got once, got %d times", client.gets)
+	}
+
+	if _, err := cache.Get(ctx, client, "space", "staging"); err != nil {
+		t.Fatal(err)
+	}
+	if client.gets != 2 {
+		t.Errorf("another environment should be got separately, got %d times", client.gets)
+	}
+}
+
+func TestEnvironmentCache_GetError(t *testing.T) {
+	ctx := context.Background()
+	cache := newEnvironmentCache()
+	client := &countingEnvironmentClient{err: errors.New("unavailable")}
+
+	if _, err := cache.Get(ctx, client, "space", "master"); err == nil {
+		t.Fatal("Get() should fail")
+	}
+
+	client.err = nil
+	if _, err := cache.Get(ctx, client, "space", "master"); err != nil {
+		t.Fatalf("Get() should retry after a failure: %v", err)
+	}
+	if client.gets != 2 {
+		t.Errorf("failed lookup should not be cached, got %d times", client.gets)
+	}
+}
+
+func TestCachingEnvironmentClient(t *testing.T) {
+	ctx := context.Background()
+	cache := newEnvironmentCache()
+	client := &countingEnvironmentClient{}
+
+	env, _ := cache.Get(ctx, client, "space", "staging")
+	environments := &cachingEnvironmentClient{ContentfulEnvironmentClient: client, cache: cache}
+	if err := environments.Upsert(ctx, "space", env); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := cache.Get(ctx, client, "space", "staging"); err != nil {
+		t.Fatal(err)
+	}
+	if client.gets != 2 {
+		t.Errorf("updated environment should be got again, got %d times", client.gets)
+	}
+}
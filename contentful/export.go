@@ -0,0 +1,590 @@
+package contentful
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	contentful "github.com/kitagry/contentful-go"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ExportOptions selects the environment which Export writes the configuration of.
+type ExportOptions struct {
+	CMAToken string
+	// BaseURL is the base URL of the Content Management API. Defaults to https://api.contentful.com.
+	BaseURL       string
+	SpaceID       string
+	EnvironmentID string
+}
+
+// Export writes the Terraform configuration of an environment to w: the content types and entries of the
+// environment with their editor interfaces, and the locales, assets, webhooks and API keys of its space. Every resource is followed by an
+// import block, so that applying the configuration adopts the existing entities instead of creating them.
+//
+// Locales and assets are those of the master environment, which contentful_locale and contentful_asset manage.
+func Export(ctx context.Context, w io.Writer, opts ExportOptions) error {
+	client := contentful.NewCMA(opts.CMAToken)
+	if opts.BaseURL != "" {
+		client.BaseURL = strings.TrimSuffix(opts.BaseURL, "/")
+	}
+	client.SetHTTPClient(defaultHTTPClient)
+
+	return exportEnvironment(ctx, newCMAClient(client), w, opts.SpaceID, opts.EnvironmentID)
+}
+
+func exportEnvironment(ctx context.Context, c *cmaClient, w io.Writer, spaceID, envID string) error {
+	e := &exporter{
+		c:       c,
+		spaceID: spaceID,
+		envID:   envID,
+		file:    hclwrite.NewEmptyFile(),
+		names:   map[string]map[string]bool{},
+	}
+	if err := e.export(ctx); err != nil {
+		return err
+	}
+	_, err := w.Write(append(bytes.TrimRight(e.file.Bytes(), "\n"), '\n'))
+	return err
+}
+
+// exporter builds the configuration of an environment in file.
+type exporter struct {
+	c       *cmaClient
+	spaceID string
+	envID   string
+
+	file *hclwrite.File
+	// names are the names of the resources written so far, by resource type.
+	names map[string]map[string]bool
+	// defaultLocale is the code of the default locale of the space, which entries and assets are written in.
+	defaultLocale string
+}
+
+func (e *exporter) export(ctx context.Context) error {
+	body := e.file.Body()
+	appendComment(body, fmt.Sprintf("Exported from the environment %s of the space %s by terraform-provider-contentful.", e.envID, e.spaceID))
+	locals := body.AppendNewBlock("locals", nil).Body()
+	locals.SetAttributeValue("space_id", cty.StringVal(e.spaceID))
+	locals.SetAttributeValue("env_id", cty.StringVal(e.envID))
+	body.AppendNewline()
+
+	if err := e.exportLocales(ctx); err != nil {
+		return fmt.Errorf("failed to export locales: %w", err)
+	}
+	contentTypes, err := e.exportContentTypes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to export content types: %w", err)
+	}
+	for _, ct := range contentTypes {
+		if err := e.exportEditorInterface(ctx, ct); err != nil {
+			return fmt.Errorf("failed to export the editor interface of %s: %w", ct.contentType.Sys.ID, err)
+		}
+	}
+	for _, ct := range contentTypes {
+		if err := e.exportEntries(ctx, ct); err != nil {
+			return fmt.Errorf("failed to export entries of %s: %w", ct.contentType.Sys.ID, err)
+		}
+	}
+	if err := e.exportAssets(ctx); err != nil {
+		return fmt.Errorf("failed to export assets: %w", err)
+	}
+	if err := e.exportWebhooks(ctx); err != nil {
+		return fmt.Errorf("failed to export webhooks: %w", err)
+	}
+	if err := e.exportAPIKeys(ctx); err != nil {
+		return fmt.Errorf("failed to export API keys: %w", err)
+	}
+	return nil
+}
+
+func (e *exporter) spacePath(collection string) string {
+	return fmt.Sprintf("/spaces/%s/%s", e.spaceID, collection)
+}
+
+func (e *exporter) environmentPath(collection string) string {
+	return fmt.Sprintf("/spaces/%s/environments/%s/%s", e.spaceID, e.envID, collection)
+}
+
+func (e *exporter) exportLocales(ctx context.Context) error {
+	var locales []*contentful.Locale
+	if err := e.c.listAll(ctx, e.spacePath("locales"), nil, &locales); err != nil {
+		return err
+	}
+
+	for _, locale := range locales {
+		if locale.Default || e.defaultLocale == "" {
+			e.defaultLocale = locale.Code
+		}
+	}
+
+	for _, locale := range locales {
+		name, body := e.appendResource("contentful_locale", locale.Code)
+		setLocal(body, "space_id")
+		body.SetAttributeValue("name", cty.StringVal(locale.Name))
+		body.SetAttributeValue("code", cty.StringVal(locale.Code))
+		body.SetAttributeValue("fallback_code", cty.StringVal(locale.FallbackCode))
+		body.SetAttributeValue("optional", cty.BoolVal(locale.Optional))
+		body.SetAttributeValue("cda", cty.BoolVal(locale.CDA))
+		body.SetAttributeValue("cma", cty.BoolVal(locale.CMA))
+		e.appendImport("contentful_locale", name, e.spaceID, locale.Sys.ID)
+	}
+	return nil
+}
+
+// exportedContentType is a content type with the name of its resource, which entries refer to.
+type exportedContentType struct {
+	contentType *ContentType
+	name        string
+}
+
+func (e *exporter) exportContentTypes(ctx context.Context) ([]*exportedContentType, error) {
+	var contentTypes []*ContentType
+	if err := e.c.listAll(ctx, e.environmentPath("content_types"), nil, &contentTypes); err != nil {
+		return nil, err
+	}
+
+	exported := make([]*exportedContentType, 0, len(contentTypes))
+	for _, ct := range contentTypes {
+		name, body := e.appendResource("contentful_contenttype", ct.Sys.ID)
+		setLocal(body, "space_id")
+		setLocal(body, "env_id")
+		body.SetAttributeValue("content_type_id", cty.StringVal(ct.Sys.ID))
+		body.SetAttributeValue("name", cty.StringVal(ct.Name))
+		if ct.Description != "" {
+			body.SetAttributeValue("description", cty.StringVal(ct.Description))
+		}
+		if field, ok := findField(ct.Fields, ct.DisplayField); ok {
+			body.SetAttributeValue("display_field", cty.StringVal(apiNameOf(field)))
+		} else {
+			body.SetAttributeValue("display_field", cty.StringVal(ct.DisplayField))
+		}
+
+		var fieldAnnotations map[string][]contentful.Entity
+		if ct.Metadata != nil {
+			if ct.Metadata.Annotations != nil {
+				if ids := toStrings(flattenAnnotationLinks(ct.Metadata.Annotations.ContentType)); len(ids) > 0 {
+					body.SetAttributeValue("annotations", stringList(ids))
+				}
+				fieldAnnotations = ct.Metadata.Annotations.ContentTypeField
+			}
+			for _, t := range ct.Metadata.Taxonomy {
+				taxonomy := body.AppendNewBlock("taxonomy", nil).Body()
+				taxonomy.SetAttributeValue("type", cty.StringVal(t.Sys.LinkType))
+				taxonomy.SetAttributeValue("id", cty.StringVal(t.Sys.ID))
+				if t.Required {
+					taxonomy.SetAttributeValue("required", cty.True)
+				}
+			}
+		}
+
+		for _, field := range ct.Fields {
+			if err := exportField(body.AppendNewBlock("field", nil).Body(), field, fieldAnnotations[field.ID]); err != nil {
+				return nil, fmt.Errorf("field %s of %s: %w", apiNameOf(field), ct.Sys.ID, err)
+			}
+		}
+
+		e.appendImport("contentful_contenttype", name, e.spaceID, e.envID, ct.Sys.ID)
+		exported = append(exported, &exportedContentType{contentType: ct, name: name})
+	}
+	return exported, nil
+}
+
+func exportField(body *hclwrite.Body, field *ContentTypeField, annotations []contentful.Entity) error {
+	body.SetAttributeValue("id", cty.StringVal(apiNameOf(field)))
+	body.SetAttributeValue("name", cty.StringVal(field.Name))
+	body.SetAttributeValue("type", cty.StringVal(field.Type))
+	if field.LinkType != "" {
+		body.SetAttributeValue("link_type", cty.StringVal(field.LinkType))
+	}
+	for _, flag := range []struct {
+		key   string
+		value bool
+	}{
+		{"required", field.Required},
+		{"localized", field.Localized},
+		{"disabled", field.Disabled},
+		{"omitted", field.Omitted},
+	} {
+		if flag.value {
+			body.SetAttributeValue(flag.key, cty.True)
+		}
+	}
+	if len(field.Validations) > 0 {
+		validations, err := validationsValue(field.Validations)
+		if err != nil {
+			return err
+		}
+		body.SetAttributeValue("validations", validations)
+	}
+	if len(annotations) > 0 {
+		body.SetAttributeValue("annotations", stringList(toStrings(flattenAnnotationLinks(annotations))))
+	}
+	if len(field.DefaultValue) > 0 {
+		defaultValue := map[string]cty.Value{}
+		for locale, value := range flattenDefaultValue(field.DefaultValue) {
+			defaultValue[locale] = cty.StringVal(value.(string))
+		}
+		body.SetAttributeValue("default_value", cty.MapVal(defaultValue))
+	}
+
+	if field.Items != nil {
+		items := body.AppendNewBlock("items", nil).Body()
+		items.SetAttributeValue("type", cty.StringVal(field.Items.Type))
+		if field.Items.LinkType != "" {
+			items.SetAttributeValue("link_type", cty.StringVal(field.Items.LinkType))
+		}
+		if len(field.Items.Validations) > 0 {
+			validations, err := validationsValue(field.Items.Validations)
+			if err != nil {
+				return err
+			}
+			items.SetAttributeValue("validations", validations)
+		}
+	}
+	for _, resource := range field.AllowedResources {
+		allowed := body.AppendNewBlock("allowed_resources", nil).Body()
+		allowed.SetAttributeValue("type", cty.StringVal(resource.Type))
+		allowed.SetAttributeValue("source", cty.StringVal(resource.Source))
+		allowed.SetAttributeValue("content_types", stringList(resource.ContentTypes))
+	}
+	return nil
+}
+
+// validationsValue encodes the validations as JSON strings, which contentful.ParseValidations reads back.
+func validationsValue(validations []contentful.FieldValidation) (cty.Value, error) {
+	values := make([]string, 0, len(validations))
+	for _, v := range validations {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return cty.NilVal, err
+		}
+		values = append(values, string(b))
+	}
+	return stringList(values), nil
+}
+
+func (e *exporter) exportEditorInterface(ctx context.Context, ct *exportedContentType) error {
+	req, err := e.c.newRequest(ctx, http.MethodGet, e.environmentPath(editorInterfacePath(ct.contentType.Sys.ID)), nil, nil)
+	if err != nil {
+		return err
+	}
+	var doc EntityDocument
+	if err := e.c.do(req, &doc); err != nil {
+		return err
+	}
+	controls, err := flattenEditorInterfaceWidgets(doc["controls"], "fieldId")
+	if err != nil {
+		return err
+	}
+	sidebar, err := flattenEditorInterfaceWidgets(doc["sidebar"], "")
+	if err != nil {
+		return err
+	}
+
+	name, body := e.appendResource("contentful_editor_interface", ct.contentType.Sys.ID)
+	setLocal(body, "space_id")
+	setLocal(body, "env_id")
+	body.SetAttributeTraversal("content_type_id", hcl.Traversal{
+		hcl.TraverseRoot{Name: "contentful_contenttype"},
+		hcl.TraverseAttr{Name: ct.name},
+		hcl.TraverseAttr{Name: "content_type_id"},
+	})
+	for _, raw := range controls {
+		control := raw.(map[string]interface{})
+		// Controls without a widget have the default widget of their field.
+		if control["widget_id"] == "" {
+			continue
+		}
+		block := body.AppendNewBlock("control", nil).Body()
+		block.SetAttributeValue("field_id", cty.StringVal(control["field_id"].(string)))
+		setEditorInterfaceWidget(block, control)
+	}
+	for _, raw := range sidebar {
+		widget := raw.(map[string]interface{})
+		block := body.AppendNewBlock("sidebar", nil).Body()
+		setEditorInterfaceWidget(block, widget)
+		if widget["disabled"].(bool) {
+			block.SetAttributeValue("disabled", cty.True)
+		}
+	}
+	e.appendImport("contentful_editor_interface", name, e.spaceID, e.envID, ct.contentType.Sys.ID)
+	return nil
+}
+
+func setEditorInterfaceWidget(body *hclwrite.Body, widget map[string]interface{}) {
+	body.SetAttributeValue("widget_namespace", cty.StringVal(widget["widget_namespace"].(string)))
+	body.SetAttributeValue("widget_id", cty.StringVal(widget["widget_id"].(string)))
+	if settings := widget["settings_json"].(string); settings != "" {
+		body.SetAttributeValue("settings_json", cty.StringVal(settings))
+	}
+}
+
+func (e *exporter) exportEntries(ctx context.Context, ct *exportedContentType) error {
+	var entries []*contentful.Entry
+	query := url.Values{"content_type": []string{ct.contentType.Sys.ID}}
+	if err := e.c.listAll(ctx, e.environmentPath("entries"), query, &entries); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		// contentful_entry takes the values of fields which are not of text types, such as links, as JSON.
+		var fields []map[string]string
+		for _, field := range ct.contentType.Fields {
+			id := apiNameOf(field)
+			values, _ := entry.Fields[id].(map[string]interface{})
+			for _, locale := range sortedLocales(values) {
+				content, ok := values[locale].(string)
+				if !ok {
+					b, err := json.Marshal(values[locale])
+					if err != nil {
+						return fmt.Errorf("failed to encode field %s of entry %s in locale %s: %w", id, entry.Sys.ID, locale, err)
+					}
+					content = string(b)
+				}
+				fields = append(fields, map[string]string{"id": id, "locale": locale, "content": content})
+			}
+		}
+		if len(fields) == 0 {
+			appendComment(e.file.Body(), fmt.Sprintf("The entry %s is not exported, because it has no values.", entry.Sys.ID))
+			e.file.Body().AppendNewline()
+			continue
+		}
+
+		name, body := e.appendResource("contentful_entry", ct.contentType.Sys.ID+"_"+entry.Sys.ID)
+		setLocal(body, "space_id")
+		setLocal(body, "env_id")
+		body.SetAttributeValue("entry_id", cty.StringVal(entry.Sys.ID))
+		body.SetAttributeTraversal("contenttype_id", hcl.Traversal{
+			hcl.TraverseRoot{Name: "contentful_contenttype"},
+			hcl.TraverseAttr{Name: ct.name},
+			hcl.TraverseAttr{Name: "content_type_id"},
+		})
+		body.SetAttributeValue("locale", cty.StringVal(e.defaultLocale))
+		for _, f := range fields {
+			field := body.AppendNewBlock("field", nil).Body()
+			field.SetAttributeValue("id", cty.StringVal(f["id"]))
+			field.SetAttributeValue("locale", cty.StringVal(f["locale"]))
+			field.SetAttributeValue("content", cty.StringVal(f["content"]))
+		}
+		setEntityState(body, entry.Sys)
+		e.appendImport("contentful_entry", name, e.spaceID, e.envID, entry.Sys.ID)
+	}
+	return nil
+}
+
+func (e *exporter) exportAssets(ctx context.Context) error {
+	var assets []*contentful.Asset
+	if err := e.c.listAll(ctx, e.spacePath("assets"), nil, &assets); err != nil {
+		return err
+	}
+
+	for _, asset := range assets {
+		var fields contentful.AssetFields
+		if asset.Fields != nil {
+			fields = *asset.Fields
+		}
+		// contentful_asset has a single file, in the locale of the asset.
+		locale := e.defaultLocale
+		if _, ok := fields.File[locale]; !ok {
+			for l := range fields.File {
+				if locale == e.defaultLocale || l < locale {
+					locale = l
+				}
+			}
+		}
+		file := fields.File[locale]
+		if file == nil {
+			appendComment(e.file.Body(), fmt.Sprintf("The asset %s is not exported, because it has no file.", asset.Sys.ID))
+			e.file.Body().AppendNewline()
+			continue
+		}
+
+		name, body := e.appendResource("contentful_asset", asset.Sys.ID)
+		setLocal(body, "space_id")
+		body.SetAttributeValue("asset_id", cty.StringVal(asset.Sys.ID))
+		body.SetAttributeValue("locale", cty.StringVal(locale))
+
+		fieldsBody := body.AppendNewBlock("fields", nil).Body()
+		appendLocalizedTexts(fieldsBody, "title", fields.Title, locale)
+		appendLocalizedTexts(fieldsBody, "description", fields.Description, locale)
+		fileBody := fieldsBody.AppendNewBlock("file", nil).Body()
+		if file.URL != "" {
+			fileBody.SetAttributeValue("url", cty.StringVal(file.URL))
+		}
+		if file.UploadURL != "" {
+			fileBody.SetAttributeValue("upload", cty.StringVal(file.UploadURL))
+		}
+		fileBody.SetAttributeValue("file_name", cty.StringVal(file.FileName))
+		fileBody.SetAttributeValue("content_type", cty.StringVal(file.ContentType))
+
+		setEntityState(body, asset.Sys)
+		e.appendImport("contentful_asset", name, e.spaceID, asset.Sys.ID)
+	}
+	return nil
+}
+
+// appendLocalizedTexts appends a block for each locale of texts. The blocks are required, so an empty text of
+// locale is appended when there are no texts.
+func appendLocalizedTexts(body *hclwrite.Body, blockType string, texts map[string]string, locale string) {
+	if len(texts) == 0 {
+		texts = map[string]string{locale: ""}
+	}
+	locales := make([]string, 0, len(texts))
+	for l := range texts {
+		locales = append(locales, l)
+	}
+	sort.Strings(locales)
+	for _, l := range locales {
+		block := body.AppendNewBlock(blockType, nil).Body()
+		block.SetAttributeValue("content", cty.StringVal(texts[l]))
+		block.SetAttributeValue("locale", cty.StringVal(l))
+	}
+}
+
+func (e *exporter) exportWebhooks(ctx context.Context) error {
+	var webhooks []*contentful.Webhook
+	if err := e.c.listAll(ctx, e.spacePath("webhook_definitions"), nil, &webhooks); err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		name, body := e.appendResource("contentful_webhook", webhook.Name)
+		setLocal(body, "space_id")
+		body.SetAttributeValue("name", cty.StringVal(webhook.Name))
+		body.SetAttributeValue("url", cty.StringVal(webhook.URL))
+		if webhook.HTTPBasicUsername != "" {
+			body.SetAttributeValue("http_basic_auth_username", cty.StringVal(webhook.HTTPBasicUsername))
+			appendComment(body, "The API does not return http_basic_auth_password, so set it before applying.")
+		}
+		if len(webhook.Headers) > 0 {
+			headers := map[string]cty.Value{}
+			for _, header := range webhook.Headers {
+				headers[header.Key] = cty.StringVal(header.Value)
+			}
+			body.SetAttributeValue("headers", cty.MapVal(headers))
+		}
+		body.SetAttributeValue("topics", stringList(webhook.Topics))
+		e.appendImport("contentful_webhook", name, e.spaceID, webhook.Sys.ID)
+	}
+	return nil
+}
+
+func (e *exporter) exportAPIKeys(ctx context.Context) error {
+	var apiKeys []*contentful.APIKey
+	if err := e.c.listAll(ctx, e.spacePath("api_keys"), nil, &apiKeys); err != nil {
+		return err
+	}
+
+	for _, apiKey := range apiKeys {
+		name, body := e.appendResource("contentful_apikey", apiKey.Name)
+		setLocal(body, "space_id")
+		body.SetAttributeValue("name", cty.StringVal(apiKey.Name))
+		if apiKey.Description != "" {
+			body.SetAttributeValue("description", cty.StringVal(apiKey.Description))
+		}
+		e.appendImport("contentful_apikey", name, e.spaceID, apiKey.Sys.ID)
+	}
+	return nil
+}
+
+// appendResource appends a resource block named after label, which is made unique among the resources of the type.
+func (e *exporter) appendResource(resourceType, label string) (string, *hclwrite.Body) {
+	if e.names[resourceType] == nil {
+		e.names[resourceType] = map[string]bool{}
+	}
+	base := resourceName(label)
+	name := base
+	for i := 2; e.names[resourceType][name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	e.names[resourceType][name] = true
+
+	return name, e.file.Body().AppendNewBlock("resource", []string{resourceType, name}).Body()
+}
+
+// appendImport appends the import block of a resource, whose import ID is the IDs joined by slashes.
+func (e *exporter) appendImport(resourceType, name string, ids ...string) {
+	body := e.file.Body()
+	body.AppendNewline()
+	block := body.AppendNewBlock("import", nil).Body()
+	block.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: name},
+	})
+	block.SetAttributeValue("id", cty.StringVal(strings.Join(ids, "/")))
+	body.AppendNewline()
+}
+
+// resourceName converts label to a Terraform identifier, which consists of letters, digits, underscores and
+// dashes and does not start with a digit or a dash.
+func resourceName(label string) string {
+	var b strings.Builder
+	for _, r := range label {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	name := b.String()
+	if name == "" || unicode.IsDigit(rune(name[0])) || name[0] == '-' {
+		name = "_" + name
+	}
+	return name
+}
+
+func setLocal(body *hclwrite.Body, name string) {
+	body.SetAttributeTraversal(name, hcl.Traversal{
+		hcl.TraverseRoot{Name: "local"},
+		hcl.TraverseAttr{Name: name},
+	})
+}
+
+// setEntityState sets published and archived like the resources read them. An entity with changes after the last
+// publish is published, so that applying the configuration publishes the changes, which is noted in a comment.
+func setEntityState(body *hclwrite.Body, sys *contentful.Sys) {
+	status := entityStatus(sys)
+	body.SetAttributeValue("published", cty.BoolVal(hasPublishedVersion(sys)))
+	body.SetAttributeValue("archived", cty.BoolVal(status == entityStatusArchived))
+	if status == entityStatusChanged {
+		appendComment(body, "The entity has changes after the last publish, which applying the configuration publishes.")
+	}
+}
+
+func appendComment(body *hclwrite.Body, comment string) {
+	body.AppendUnstructuredTokens(hclwrite.Tokens{
+		{Type: hclsyntax.TokenComment, Bytes: []byte("# " + comment + "\n")},
+	})
+}
+
+func stringList(values []string) cty.Value {
+	if len(values) == 0 {
+		return cty.ListValEmpty(cty.String)
+	}
+	list := make([]cty.Value, 0, len(values))
+	for _, v := range values {
+		list = append(list, cty.StringVal(v))
+	}
+	return cty.ListVal(list)
+}
+
+// sortedLocales returns the locale codes of localized values in order.
+func sortedLocales(values map[string]interface{}) []string {
+	locales := make([]string, 0, len(values))
+	for locale := range values {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
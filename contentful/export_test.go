@@ -0,0 +1,275 @@
+package contentful
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+func TestExportEnvironment(t *testing.T) {
+	ctx := context.Background()
+	client, env := newFakeCMAEnvironment(t)
+
+	ct := &ContentType{
+		Sys:          &contentful.Sys{ID: "blogPost"},
+		Name:         "Blog Post",
+		DisplayField: "title",
+		Fields: []*ContentTypeField{
+			{Field: contentful.Field{ID: "title", Name: "Title", Type: "Symbol", Required: true, Validations: []contentful.FieldValidation{contentful.FieldValidationUnique{Unique: true}}}},
+			{Field: contentful.Field{ID: "tags", Name: "Tags", Type: "Array", Items: &contentful.FieldTypeArrayItem{Type: "Symbol"}}},
+		},
+	}
+	if err := upsertAndActivate(ctx, &contentTypesService{c: newCMAClient(client)}, env, ct); err != nil {
+		t.Fatal(err)
+	}
+	entry := &contentful.Entry{
+		Sys: &contentful.Sys{ID: "first"},
+		Fields: map[string]interface{}{
+			"title": map[string]interface{}{"en-US": "Hello ${name}"},
+			"tags":  map[string]interface{}{"en-US": []interface{}{"news"}},
+		},
+	}
+	if err := client.Entries.Upsert(ctx, env, "blogPost", entry); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Entries.Publish(ctx, env, entry); err != nil {
+		t.Fatal(err)
+	}
+	// The entry has changes after the last publish.
+	published, err := client.Entries.Get(ctx, env, "first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Entries.Upsert(ctx, env, "blogPost", published); err != nil {
+		t.Fatal(err)
+	}
+	entities := &environmentEntitiesService{c: newCMAClient(client)}
+	editorInterface, err := entities.Get(ctx, env, editorInterfacePath("blogPost"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	editorInterface["controls"] = []interface{}{
+		map[string]interface{}{"fieldId": "title", "widgetNamespace": "builtin", "widgetId": "singleLine", "settings": map[string]interface{}{"helpText": "The title"}},
+		map[string]interface{}{"fieldId": "tags"},
+	}
+	editorInterface["sidebar"] = []interface{}{
+		map[string]interface{}{"widgetNamespace": "sidebar-builtin", "widgetId": "publication-widget", "disabled": true},
+	}
+	if _, err := entities.Put(ctx, env, editorInterfacePath("blogPost"), editorInterface.Version(), editorInterface, ""); err != nil {
+		t.Fatal(err)
+	}
+	asset := &contentful.Asset{
+		Sys: &contentful.Sys{ID: "logo"},
+		Fields: &contentful.AssetFields{
+			Title: map[string]string{"en-US": "Logo"},
+			File:  map[string]*contentful.File{"en-US": {URL: "//images.ctfassets.net/logo.png", FileName: "logo.png", ContentType: "image/png"}},
+		},
+	}
+	if err := client.Assets.Upsert(ctx, "space", asset); err != nil {
+		t.Fatal(err)
+	}
+	webhook := &contentful.Webhook{Name: "Build site", URL: "https://example.com/build", Topics: []string{"Entry.publish"}}
+	if err := client.Webhooks.Upsert(ctx, "space", webhook); err != nil {
+		t.Fatal(err)
+	}
+	apiKey := &contentful.APIKey{Name: "Website"}
+	if err := client.APIKeys.Upsert(ctx, "space", apiKey); err != nil {
+		t.Fatal(err)
+	}
+	var locales []*contentful.Locale
+	if err := newCMAClient(client).listAll(ctx, "/spaces/space/locales", nil, &locales); err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	if err := exportEnvironment(ctx, newCMAClient(client), &b, "space", "master"); err != nil {
+		t.Fatalf("exportEnvironment() failed: %v", err)
+	}
+
+	expect := fmt.Sprintf(`# Exported from the environment master of the space space by terraform-provider-contentful.
+locals {
+  space_id = "space"
+  env_id   = "master"
+}
+
+resource "contentful_locale" "en-US" {
+  space_id      = local.space_id
+  name          = "en-US"
+  code          = "en-US"
+  fallback_code = ""
+  optional      = false
+  cda           = true
+  cma           = true
+}
+
+import {
+  to = contentful_locale.en-US
+  id = "space/%s"
+}
+
+resource "contentful_contenttype" "blogPost" {
+  space_id        = local.space_id
+  env_id          = local.env_id
+  content_type_id = "blogPost"
+  name            = "Blog Post"
+  display_field   = "title"
+  field {
+    id          = "title"
+    name        = "Title"
+    type        = "Symbol"
+    required    = true
+    validations = ["{\"unique\":true}"]
+  }
+  field {
+    id   = "tags"
+    name = "Tags"
+    type = "Array"
+    items {
+      type = "Symbol"
+    }
+  }
+}
+
+import {
+  to = contentful_contenttype.blogPost
+  id = "space/master/blogPost"
+}
+
+resource "contentful_editor_interface" "blogPost" {
+  space_id        = local.space_id
+  env_id          = local.env_id
+  content_type_id = contentful_contenttype.blogPost.content_type_id
+  control {
+    field_id         = "title"
+    widget_namespace = "builtin"
+    widget_id        = "singleLine"
+    settings_json    = "{\"helpText\":\"The title\"}"
+  }
+  sidebar {
+    widget_namespace = "sidebar-builtin"
+    widget_id        = "publication-widget"
+    disabled         = true
+  }
+}
+
+import {
+  to = contentful_editor_interface.blogPost
+  id = "space/master/blogPost"
+}
+
+resource "contentful_entry" "blogPost_first" {
+  space_id       = local.space_id
+  env_id         = local.env_id
+  entry_id       = "first"
+  contenttype_id = contentful_contenttype.blogPost.content_type_id
+  locale         = "en-US"
+  field {
+    id      = "title"
+    locale  = "en-US"
+    content = "Hello $${name}"
+  }
+  field {
+    id      = "tags"
+    locale  = "en-US"
+    content = "[\"news\"]"
+  }
+  published = true
+  archived  = false
+  # The entity has changes after the last publish, which applying the configuration publishes.
+}
+
+import {
+  to = contentful_entry.blogPost_first
+  id = "space/master/first"
+}
+
+resource "contentful_asset" "logo" {
+  space_id = local.space_id
+  asset_id = "logo"
+  locale   = "en-US"
+  fields {
+    title {
+      content = "Logo"
+      locale  = "en-US"
+    }
+    description {
+      content = ""
+      locale  = "en-US"
+    }
+    file {
+      url          = "//images.ctfassets.net/logo.png"
+      file_name    = "logo.png"
+      content_type = "image/png"
+    }
+  }
+  published = false
+  archived  = false
+}
+
+import {
+  to = contentful_asset.logo
+  id = "space/logo"
+}
+
+resource "contentful_webhook" "Build_site" {
+  space_id = local.space_id
+  name     = "Build site"
+  url      = "https://example.com/build"
+  topics   = ["Entry.publish"]
+}
+
+import {
+  to = contentful_webhook.Build_site
+  id = "space/%s"
+}
+
+resource "contentful_apikey" "Website" {
+  space_id = local.space_id
+  name     = "Website"
+}
+
+import {
+  to = contentful_apikey.Website
+  id = "space/%s"
+}
+`, locales[0].Sys.ID, webhook.Sys.ID, apiKey.Sys.ID)
+	if diff := cmp.Diff(expect, b.String()); diff != "" {
+		t.Errorf("exportEnvironment() result diff (-expect, +got)\n%s", diff)
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	tests := map[string]struct {
+		label  string
+		expect string
+	}{
+		"identifier should be kept": {
+			label:  "blogPost",
+			expect: "blogPost",
+		},
+		"spaces and symbols should be replaced": {
+			label:  "Build site (prod)",
+			expect: "Build_site__prod_",
+		},
+		"leading digit should be prefixed": {
+			label:  "4xYz",
+			expect: "_4xYz",
+		},
+		"non ASCII letters should be replaced": {
+			label:  "café",
+			expect: "caf_",
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			if got := resourceName(tt.label); got != tt.expect {
+				t.Errorf("resourceName(%q) = %q, expect %q", tt.label, got, tt.expect)
+			}
+		})
+	}
+}
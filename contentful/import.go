@@ -0,0 +1,53 @@
+package contentful
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// importSpaceEntity imports an entity of a space by `<space_id>/<id>`.
+// idAttributes are the attributes which configure the ID of the entity, such as asset_id. They are set to the ID too.
+func importSpaceEntity(idAttributes ...string) *schema.ResourceImporter {
+	return importEntity([]string{"space_id"}, idAttributes)
+}
+
+// importEnvironmentEntity imports an entity of an environment by `<space_id>/<env_id>/<id>`.
+// idAttributes are the attributes which configure the ID of the entity, such as entry_id. They are set to the ID too.
+func importEnvironmentEntity(idAttributes ...string) *schema.ResourceImporter {
+	return importEntity([]string{"space_id", "env_id"}, idAttributes)
+}
+
+func importEntity(scope, idAttributes []string) *schema.ResourceImporter {
+	format := "<" + strings.Join(scope, ">/<") + ">/<id>"
+
+	return &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			parts := strings.Split(d.Id(), "/")
+			if len(parts) != len(scope)+1 {
+				return nil, fmt.Errorf("import ID %q should be %s", d.Id(), format)
+			}
+			for _, part := range parts {
+				if part == "" {
+					return nil, fmt.Errorf("import ID %q should be %s", d.Id(), format)
+				}
+			}
+
+			for i, key := range scope {
+				if err := d.Set(key, parts[i]); err != nil {
+					return nil, err
+				}
+			}
+			id := parts[len(scope)]
+			for _, key := range idAttributes {
+				if err := d.Set(key, id); err != nil {
+					return nil, err
+				}
+			}
+			d.SetId(id)
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+}
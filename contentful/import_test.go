@@ -0,0 +1,68 @@
+package contentful
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestImportEntity(t *testing.T) {
+	tests := map[string]struct {
+		resource *schema.Resource
+		id       string
+
+		expect    map[string]string
+		expectErr bool
+	}{
+		"entry should be imported by space, environment and ID": {
+			resource: resourceContentfulEntry(),
+			id:       "space/staging/entry",
+			expect:   map[string]string{"id": "entry", "space_id": "space", "env_id": "staging", "entry_id": "entry"},
+		},
+		"locale should be imported by space and ID": {
+			resource: resourceContentfulLocale(),
+			id:       "space/locale",
+			expect:   map[string]string{"id": "locale", "space_id": "space"},
+		},
+		"missing environment should fail": {
+			resource:  resourceContentfulContentType(),
+			id:        "space/post",
+			expectErr: true,
+		},
+		"empty part should fail": {
+			resource:  resourceContentfulAsset(),
+			id:        "space/",
+			expectErr: true,
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			d := tt.resource.Data(nil)
+			d.SetId(tt.id)
+
+			result, err := tt.resource.Importer.StateContext(context.Background(), d, nil)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("import should fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := map[string]string{"id": result[0].Id()}
+			for key := range tt.expect {
+				if key != "id" {
+					got[key] = result[0].Get(key).(string)
+				}
+			}
+			if diff := cmp.Diff(tt.expect, got); diff != "" {
+				t.Errorf("imported attributes diff (-expect, +got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -2,12 +2,20 @@ package contentful
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	contentful "github.com/kitagry/contentful-go"
 )
 
+func init() {
+	// Descriptions are markdown, which tfplugindocs and language servers render.
+	schema.DescriptionKind = schema.StringMarkdown
+}
+
 // Provider returns the Terraform Provider as a scheme and makes resources reachable
 func Provider() *schema.Provider {
 	return &schema.Provider{
@@ -22,31 +30,148 @@ func Provider() *schema.Provider {
 				Type:        schema.TypeString,
 				Required:    true,
 				DefaultFunc: schema.EnvDefaultFunc("CONTENTFUL_ORGANIZATION_ID", nil),
-				Description: "The organization ID",
+				Description: "The ID of the organization which spaces are created in",
+			},
+			"base_url": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("CONTENTFUL_BASE_URL", "https://api.contentful.com"),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+				Description:      "The base URL of the Content Management API. Set `https://api.eu.contentful.com` for spaces in the EU data residency region",
+			},
+			"space_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CONTENTFUL_SPACE_ID", nil),
+				Description: "The default space ID of resources which do not set `space_id`",
+			},
+			"environment_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CONTENTFUL_ENVIRONMENT_ID", nil),
+				Description: "The default environment ID of resources which do not set `env_id`",
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"contentful_space":       resourceContentfulSpace(),
-			"contentful_contenttype": resourceContentfulContentType(),
-			"contentful_apikey":      resourceContentfulAPIKey(),
-			"contentful_webhook":     resourceContentfulWebhook(),
-			"contentful_locale":      resourceContentfulLocale(),
-			"contentful_environment": resourceContentfulEnvironment(),
-			"contentful_entry":       resourceContentfulEntry(),
-			"contentful_asset":       resourceContentfulAsset(),
+			"contentful_space":               resourceContentfulSpace(),
+			"contentful_contenttype":         resourceContentfulContentType(),
+			"contentful_apikey":              resourceContentfulAPIKey(),
+			"contentful_webhook":             resourceContentfulWebhook(),
+			"contentful_locale":              resourceContentfulLocale(),
+			"contentful_environment":         resourceContentfulEnvironment(),
+			"contentful_entry":               resourceContentfulEntry(),
+			"contentful_entries":             resourceContentfulEntries(),
+			"contentful_asset":               resourceContentfulAsset(),
+			"contentful_scheduled_action":    resourceContentfulScheduledAction(),
+			"contentful_release":             resourceContentfulRelease(),
+			"contentful_bulk_action":         resourceContentfulBulkAction(),
+			"contentful_environment_content": resourceContentfulEnvironmentContent(),
+			"contentful_migration":           resourceContentfulMigration(),
+			"contentful_editor_interface":    resourceContentfulEditorInterface(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"contentful_validation":             dataSourceContentfulValidation(),
+			"contentful_contenttype_typescript": dataSourceContentfulContentTypeTypeScript(),
+			"contentful_contenttype_go":         dataSourceContentfulContentTypeGo(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
 }
 
+// providerMeta is passed to every resource as meta
+type providerMeta struct {
+	client *contentful.Client
+
+	// spaceID and environmentID are the defaults of resources which omit space_id and env_id.
+	spaceID       string
+	environmentID string
+
+	// environments caches the environments which content types and entries belong to.
+	environments *environmentCache
+}
+
 // providerConfigure sets the configuration for the Terraform Provider
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	cma := contentful.NewCMA(d.Get("cma_token").(string))
 	cma.SetOrganization(d.Get("organization_id").(string))
+	cma.BaseURL = strings.TrimSuffix(d.Get("base_url").(string), "/")
+	cma.SetHTTPClient(defaultHTTPClient)
 
 	if logBoolean != "" {
 		cma.Debug = true
 	}
 
-	return cma, nil
+	return &providerMeta{
+		client:        cma,
+		spaceID:       d.Get("space_id").(string),
+		environmentID: d.Get("environment_id").(string),
+		environments:  newEnvironmentCache(),
+	}, nil
+}
+
+// providerDefaultAttributes maps the attributes of resources to the provider attributes they fall back to.
+var providerDefaultAttributes = map[string]string{
+	"space_id": "space_id",
+	"env_id":   "environment_id",
+}
+
+// setProviderDefaults plans space_id and env_id from the provider configuration when the resource omits them.
+// The planned value is compared with the state as if it was configured, so a ForceNew attribute still replaces
+// the resource when the provider default changes.
+func setProviderDefaults(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return nil
+	}
+	defaults := map[string]string{
+		"space_id": meta.spaceID,
+		"env_id":   meta.environmentID,
+	}
+
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	for key, providerKey := range providerDefaultAttributes {
+		if !config.Type().HasAttribute(key) {
+			continue
+		}
+		if v := config.GetAttr(key); !v.IsNull() {
+			continue
+		}
+
+		value := defaults[key]
+		if value == "" {
+			return fmt.Errorf("%s must be set on the resource or as %s of the provider", key, providerKey)
+		}
+		if d.Get(key).(string) != value {
+			if err := d.SetNew(key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setDataSourceProviderDefaults sets space_id and env_id of a data source from the provider configuration when
+// the data source omits them. Data sources are read without a plan, so they cannot use setProviderDefaults.
+func setDataSourceProviderDefaults(d *schema.ResourceData, meta *providerMeta) error {
+	defaults := map[string]string{
+		"space_id": meta.spaceID,
+		"env_id":   meta.environmentID,
+	}
+
+	for key, providerKey := range providerDefaultAttributes {
+		if d.Get(key).(string) != "" {
+			continue
+		}
+		if defaults[key] == "" {
+			return fmt.Errorf("%s must be set on the data source or as %s of the provider", key, providerKey)
+		}
+		if err := d.Set(key, defaults[key]); err != nil {
+			return err
+		}
+	}
+	return nil
 }
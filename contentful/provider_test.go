@@ -1,9 +1,15 @@
 package contentful
 
 import (
+	"context"
+	"os"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	contentful "github.com/kitagry/contentful-go"
+	"github.com/kitagry/terraform-provider-contentful/internal/fakecma"
 )
 
 var (
@@ -13,17 +19,90 @@ var (
 
 func init() {
 	testAccProvider = Provider()
+	if os.Getenv("CONTENTFUL_FAKE_CMA") != "" {
+		useFakeCMA()
+	}
 	testAccProviders = map[string]*schema.Provider{
 		"contentful": testAccProvider,
 	}
 }
 
+// useFakeCMA points the provider at an in-process fake of the Content Management API,
+// so that acceptance tests run without a Contentful account or network access.
+// The space SPACE_ID and the environment ENV_ID are created in the fake before the tests start.
+func useFakeCMA() {
+	server := fakecma.NewServer()
+	server.AddSpace(spaceID, "Terraform Acc Test Space")
+	if envID != "" && envID != "master" {
+		server.AddEnvironment(spaceID, envID)
+	}
+
+	if CMAToken == "" {
+		CMAToken = "fake-token"
+		os.Setenv("CONTENTFUL_MANAGEMENT_TOKEN", CMAToken)
+	}
+	if orgID == "" {
+		orgID = "fake-organization"
+		os.Setenv("CONTENTFUL_ORGANIZATION_ID", orgID)
+	}
+
+	os.Setenv("CONTENTFUL_BASE_URL", server.URL)
+}
+
+// newFakeCMAEnvironment starts a fake of the Content Management API with the space "space", and returns a client
+// of the fake and its master environment. The fake stops when the test finishes.
+func newFakeCMAEnvironment(t *testing.T) (*contentful.Client, *contentful.Environment) {
+	t.Helper()
+
+	server := fakecma.NewServer()
+	t.Cleanup(server.Close)
+	server.AddSpace("space", "Test Space")
+
+	client := contentful.NewCMA("token")
+	client.BaseURL = server.URL
+	env, err := client.Environments.Get(context.Background(), "space", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, env
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
 	}
 }
 
+// TestProvider_descriptions ensures the schemas document themselves, since the docs are generated from them.
+func TestProvider_descriptions(t *testing.T) {
+	var check func(path string, m map[string]*schema.Schema)
+	check = func(path string, m map[string]*schema.Schema) {
+		for k, s := range m {
+			if s.Description == "" {
+				t.Errorf("%s.%s has no description", path, k)
+			}
+			if r, ok := s.Elem.(*schema.Resource); ok {
+				check(path+"."+k, r.Schema)
+			}
+		}
+	}
+
+	p := Provider()
+	check("provider", p.Schema)
+	for name, r := range p.ResourcesMap {
+		if r.Description == "" {
+			t.Errorf("%s has no description", name)
+		}
+		check(name, r.Schema)
+	}
+	for name, r := range p.DataSourcesMap {
+		if r.Description == "" {
+			t.Errorf("%s has no description", name)
+		}
+		check("data."+name, r.Schema)
+	}
+}
+
 func TestProvider_impl(t *testing.T) {
 	_ = Provider()
 }
@@ -43,3 +122,139 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("ENV_ID must set with a valid Contentful Environment ID for acceptance tests")
 	}
 }
+
+func TestProviderConfigure(t *testing.T) {
+	tests := map[string]struct {
+		raw map[string]interface{}
+
+		expectBaseURL string
+	}{
+		"default hosts": {
+			raw: map[string]interface{}{
+				"cma_token":       "token",
+				"organization_id": "org",
+			},
+			expectBaseURL: "https://api.contentful.com",
+		},
+		"EU data residency hosts": {
+			raw: map[string]interface{}{
+				"cma_token":       "token",
+				"organization_id": "org",
+				"base_url":        "https://api.eu.contentful.com/",
+			},
+			expectBaseURL: "https://api.eu.contentful.com",
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			// t.Setenv restores the variable, which is unset for the default to apply.
+			t.Setenv("CONTENTFUL_BASE_URL", "")
+			os.Unsetenv("CONTENTFUL_BASE_URL")
+
+			d := schema.TestResourceDataRaw(t, Provider().Schema, tt.raw)
+			meta, diags := providerConfigure(context.Background(), d)
+			if diags.HasError() {
+				t.Fatalf("providerConfigure failed: %v", diags)
+			}
+
+			m := meta.(*providerMeta)
+			if m.client.BaseURL != tt.expectBaseURL {
+				t.Errorf("client.BaseURL = %s, expect %s", m.client.BaseURL, tt.expectBaseURL)
+			}
+		})
+	}
+}
+
+func TestSetProviderDefaults(t *testing.T) {
+	tests := map[string]struct {
+		meta   *providerMeta
+		config map[string]interface{}
+		state  map[string]string
+
+		expectSpaceID     string
+		expectRequiresNew bool
+		expectErr         bool
+	}{
+		"omitted attributes should be planned from the provider": {
+			meta:          &providerMeta{spaceID: "provider-space", environmentID: "provider-env"},
+			config:        map[string]interface{}{},
+			expectSpaceID: "provider-space",
+		},
+		"attributes on the resource should take precedence": {
+			meta:          &providerMeta{spaceID: "provider-space", environmentID: "provider-env"},
+			config:        map[string]interface{}{"space_id": "resource-space", "env_id": "resource-env"},
+			state:         map[string]string{"space_id": "resource-space", "env_id": "resource-env"},
+			expectSpaceID: "resource-space",
+		},
+		"unchanged provider default should keep the resource": {
+			meta:          &providerMeta{spaceID: "provider-space", environmentID: "provider-env"},
+			config:        map[string]interface{}{},
+			state:         map[string]string{"space_id": "provider-space", "env_id": "provider-env"},
+			expectSpaceID: "provider-space",
+		},
+		"changing the provider default should replace the resource": {
+			meta:              &providerMeta{spaceID: "new-space", environmentID: "provider-env"},
+			config:            map[string]interface{}{},
+			state:             map[string]string{"space_id": "old-space", "env_id": "provider-env"},
+			expectSpaceID:     "new-space",
+			expectRequiresNew: true,
+		},
+		"omitted attributes without provider defaults should fail": {
+			meta:      &providerMeta{},
+			config:    map[string]interface{}{},
+			expectErr: true,
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			r := resourceContentfulContentType()
+
+			raw := map[string]interface{}{
+				"name":          "Post",
+				"display_field": "title",
+				"field": []interface{}{
+					map[string]interface{}{"id": "title", "name": "Title", "type": "Symbol"},
+				},
+			}
+			rawConfig := map[string]cty.Value{
+				"space_id": cty.NullVal(cty.String),
+				"env_id":   cty.NullVal(cty.String),
+			}
+			for k, v := range tt.config {
+				raw[k] = v
+				rawConfig[k] = cty.StringVal(v.(string))
+			}
+
+			state := &terraform.InstanceState{RawConfig: cty.ObjectVal(rawConfig)}
+			if tt.state != nil {
+				state.ID = "post"
+				state.Attributes = tt.state
+			}
+
+			diff, err := r.SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(raw), tt.meta)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("Diff should fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Diff failed: %v", err)
+			}
+
+			// An unchanged attribute is not in the diff and keeps the value of the state.
+			gotSpaceID := tt.state["space_id"]
+			if attr, ok := diff.Attributes["space_id"]; ok {
+				gotSpaceID = attr.New
+			}
+			if gotSpaceID != tt.expectSpaceID {
+				t.Errorf("planned space_id = %s, expect %s", gotSpaceID, tt.expectSpaceID)
+			}
+			if tt.state != nil && diff.RequiresNew() != tt.expectRequiresNew {
+				t.Errorf("RequiresNew() = %v, expect %v", diff.RequiresNew(), tt.expectRequiresNew)
+			}
+		})
+	}
+}
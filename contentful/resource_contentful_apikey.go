@@ -10,31 +10,40 @@ import (
 
 func resourceContentfulAPIKey() *schema.Resource {
 	return &schema.Resource{
+		Description:   "Manages an API key of a space for the Content Delivery API",
 		CreateContext: wrapApiKey(resourceCreateAPIKey),
 		ReadContext:   wrapApiKey(resourceReadAPIKey),
 		UpdateContext: wrapApiKey(resourceUpdateAPIKey),
 		DeleteContext: wrapApiKey(resourceDeleteAPIKey),
+		Importer:      importSpaceEntity(),
+		CustomizeDiff: setProviderDefaults,
 
 		Schema: map[string]*schema.Schema{
 			"version": {
-				Type:     schema.TypeInt,
-				Computed: true,
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The current version of the API key, which increases on every change",
 			},
 			"access_token": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The access token of the Content Delivery API",
 			},
 			"space_id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the space. Defaults to `space_id` of the provider",
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the API key",
 			},
 			"description": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the API key",
 			},
 		},
 	}
@@ -42,7 +51,7 @@ func resourceContentfulAPIKey() *schema.Resource {
 
 func wrapApiKey(f func(ctx context.Context, d *schema.ResourceData, apiKey ContentfulAPIKeyClient) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-		client := m.(*contentful.Client)
+		client := m.(*providerMeta).client
 		return f(ctx, d, client.APIKeys)
 	}
 }
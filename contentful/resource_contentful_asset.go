@@ -2,35 +2,57 @@ package contentful
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	contentful "github.com/kitagry/contentful-go"
 )
 
+// assetProcessPollInterval is the interval to check whether the files of an asset have been processed.
+var assetProcessPollInterval = time.Second
+
 func resourceContentfulAsset() *schema.Resource {
 	return &schema.Resource{
+		Description:   "Manages an asset, a media file with its title and description, in the master environment of a space",
 		CreateContext: wrapAsset(resourceCreateAsset),
 		ReadContext:   wrapAsset(resourceReadAsset),
 		UpdateContext: wrapAsset(resourceUpdateAsset),
 		DeleteContext: wrapAsset(resourceDeleteAsset),
+		Importer:      importSpaceEntity("asset_id"),
+		CustomizeDiff: customdiff.All(setProviderDefaults, planRepublish),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"asset_id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the asset",
 			},
 			"version": {
-				Type:     schema.TypeInt,
-				Computed: true,
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The current version of the asset, which increases on every change",
 			},
 			"locale": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The code of the locale which the file is set in",
 			},
 			"space_id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the space. Defaults to `space_id` of the provider",
 			},
 			"fields": {
 				Type:     schema.TypeList,
@@ -43,15 +65,18 @@ func resourceContentfulAsset() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"content": {
-										Type:     schema.TypeString,
-										Required: true,
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The title in the locale",
 									},
 									"locale": {
-										Type:     schema.TypeString,
-										Required: true,
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The locale code",
 									},
 								},
 							},
+							Description: "The title of the asset in each locale",
 						},
 						"description": {
 							Type:     schema.TypeList,
@@ -59,15 +84,18 @@ func resourceContentfulAsset() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"content": {
-										Type:     schema.TypeString,
-										Required: true,
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The description in the locale",
 									},
 									"locale": {
-										Type:     schema.TypeString,
-										Required: true,
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The locale code",
 									},
 								},
 							},
+							Description: "The description of the asset in each locale",
 						},
 						"file": {
 							Type:     schema.TypeSet,
@@ -75,21 +103,26 @@ func resourceContentfulAsset() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"url": {
-										Type:     schema.TypeString,
-										Optional: true,
+										Type:        schema.TypeString,
+										Optional:    true,
+										Computed:    true,
+										Description: "The URL of the processed file on the Contentful CDN",
 									},
 									"upload": {
-										Type:     schema.TypeString,
-										Optional: true,
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "A public URL which Contentful downloads the file from when processing the asset",
 									},
 									"details": {
 										Type:     schema.TypeSet,
 										Optional: true,
+										Computed: true,
 										Elem: &schema.Resource{
 											Schema: map[string]*schema.Schema{
 												"size": {
-													Type:     schema.TypeInt,
-													Required: true,
+													Type:        schema.TypeInt,
+													Required:    true,
+													Description: "The size of the file in bytes",
 												},
 												"image": {
 													Type:     schema.TypeSet,
@@ -97,40 +130,61 @@ func resourceContentfulAsset() *schema.Resource {
 													Elem: &schema.Resource{
 														Schema: map[string]*schema.Schema{
 															"width": {
-																Type:     schema.TypeInt,
-																Required: true,
+																Type:        schema.TypeInt,
+																Required:    true,
+																Description: "The width of the image in pixels",
 															},
 															"height": {
-																Type:     schema.TypeInt,
-																Required: true,
+																Type:        schema.TypeInt,
+																Required:    true,
+																Description: "The height of the image in pixels",
 															},
 														},
 													},
+													Description: "The dimensions of an image",
 												},
 											},
 										},
+										Description: "The size of the file and the dimensions of an image",
 									},
 									"file_name": {
-										Type:     schema.TypeString,
-										Optional: true,
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The name of the file",
 									},
 									"content_type": {
-										Type:     schema.TypeString,
-										Required: true,
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The MIME type of the file, such as `image/png`",
 									},
 								},
 							},
+							Set:         hashAssetFile,
+							Description: "The file of the asset. The URL, the details and a configured name of the file are refreshed from Contentful, while `upload` is kept as configured",
 						},
 					},
 				},
+				Description: "The fields of the asset",
 			},
 			"published": {
-				Type:     schema.TypeBool,
-				Required: true,
+				Type:        schema.TypeBool,
+				Required:    true,
+				Description: "Whether the asset is published. An asset changed after the last publish is published again",
 			},
 			"archived": {
-				Type:     schema.TypeBool,
-				Required: true,
+				Type:        schema.TypeBool,
+				Required:    true,
+				Description: "Whether the asset is archived. An archived asset cannot be published",
+			},
+			"published_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The version of the asset when it was last published",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The publication status of the asset: `draft`, `published`, `changed` or `archived`",
 			},
 		},
 	}
@@ -138,8 +192,7 @@ func resourceContentfulAsset() *schema.Resource {
 
 func wrapAsset(f func(ctx context.Context, d *schema.ResourceData, client ContentfulAssetClient) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-		client := m.(*contentful.Client)
-		return f(ctx, d, client.Assets)
+		return f(ctx, d, m.(*providerMeta).client.Assets)
 	}
 }
 
@@ -188,7 +241,8 @@ func resourceCreateAsset(ctx context.Context, d *schema.ResourceData, client Con
 		},
 	}
 
-	if url, ok := file["url"].(string); ok {
+	// The URL is refreshed from Contentful, so it is only sent when the file is not uploaded again.
+	if url, ok := file["url"].(string); ok && !isAssetFileUploaded(file) {
 		asset.Fields.File[d.Get("locale").(string)].URL = url
 	}
 
@@ -214,18 +268,12 @@ func resourceCreateAsset(ctx context.Context, d *schema.ResourceData, client Con
 
 	d.SetId(asset.Sys.ID)
 
-	if err := setAssetProperties(d, asset); err != nil {
-		diags = append(diags, contentfulErrorToDiagnostic(err)...)
-		return
-	}
-
-	err = setAssetState(ctx, d, client)
-	if err != nil {
+	if err := waitForAssetProcessed(ctx, client, d.Get("space_id").(string), d.Id()); err != nil {
 		diags = append(diags, contentfulErrorToDiagnostic(err)...)
 		return
 	}
 
-	return
+	return setAssetState(ctx, d, client)
 }
 
 func resourceUpdateAsset(ctx context.Context, d *schema.ResourceData, client ContentfulAssetClient) (diags diag.Diagnostics) {
@@ -287,7 +335,8 @@ func resourceUpdateAsset(ctx context.Context, d *schema.ResourceData, client Con
 		},
 	}
 
-	if url, ok := file["url"].(string); ok {
+	// The URL is refreshed from Contentful, so it is only sent when the file is not uploaded again.
+	if url, ok := file["url"].(string); ok && !isAssetFileUploaded(file) {
 		asset.Fields.File[d.Get("locale").(string)].URL = url
 	}
 
@@ -313,41 +362,51 @@ func resourceUpdateAsset(ctx context.Context, d *schema.ResourceData, client Con
 
 	d.SetId(asset.Sys.ID)
 
-	if err := setAssetProperties(d, asset); err != nil {
-		diags = append(diags, contentfulErrorToDiagnostic(err)...)
-		return
-	}
-
-	err = setAssetState(ctx, d, client)
-	if err != nil {
+	if err := waitForAssetProcessed(ctx, client, d.Get("space_id").(string), d.Id()); err != nil {
 		diags = append(diags, contentfulErrorToDiagnostic(err)...)
 		return
 	}
 
-	return
+	return setAssetState(ctx, d, client)
 }
 
-func setAssetState(ctx context.Context, d *schema.ResourceData, client ContentfulAssetClient) (err error) {
-	spaceID := d.Get("space_id").(string)
-	assetID := d.Id()
+// waitForAssetProcessed waits until the files of the asset have been processed, which Contentful does asynchronously.
+// A processed file has the URL of the Contentful CDN, and only then the asset can be published.
+func waitForAssetProcessed(ctx context.Context, client ContentfulAssetClient, spaceID, assetID string) error {
+	err := pollUntil(ctx, assetProcessPollInterval, func() (bool, error) {
+		asset, err := client.Get(ctx, spaceID, assetID)
+		if err != nil {
+			return false, err
+		}
+		if asset.Fields == nil {
+			return true, nil
+		}
+		for _, file := range asset.Fields.File {
+			if file != nil && file.URL == "" {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("timed out waiting for the files of asset %s to be processed: %w", assetID, err)
+	}
+	return err
+}
 
-	asset, _ := client.Get(ctx, spaceID, assetID)
+func setAssetState(ctx context.Context, d *schema.ResourceData, client ContentfulAssetClient) (diags diag.Diagnostics) {
+	stateClient := &assetStateClient{client: client, spaceID: d.Get("space_id").(string), id: d.Id()}
 
-	if d.Get("published").(bool) && asset.Sys.PublishedAt == "" {
-		err = client.Publish(ctx, spaceID, asset)
-	} else if !d.Get("published").(bool) && asset.Sys.PublishedAt != "" {
-		err = client.Unpublish(ctx, spaceID, asset)
+	diags = changeEntityState(ctx, stateClient, d.Get("published").(bool), d.Get("archived").(bool))
+	if diags.HasError() {
+		return
 	}
 
-	if d.Get("archived").(bool) && asset.Sys.ArchivedAt == "" {
-		err = client.Archive(ctx, spaceID, asset)
-	} else if !d.Get("archived").(bool) && asset.Sys.ArchivedAt != "" {
-		err = client.Unarchive(ctx, spaceID, asset)
+	if err := setAssetProperties(d, stateClient.asset); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
 	}
-
-	err = setAssetProperties(d, asset)
-
-	return err
+	return
 }
 
 func resourceReadAsset(ctx context.Context, d *schema.ResourceData, client ContentfulAssetClient) (diags diag.Diagnostics) {
@@ -399,5 +458,125 @@ func setAssetProperties(d *schema.ResourceData, asset *contentful.Asset) (err er
 		return err
 	}
 
+	if err = d.Set("published_version", asset.Sys.PublishedVersion); err != nil {
+		return err
+	}
+
+	status := entityStatus(asset.Sys)
+	if err = d.Set("status", status); err != nil {
+		return err
+	}
+
+	// An entity with changes after the last publish is still published. planRepublish publishes it again.
+	if err = d.Set("published", hasPublishedVersion(asset.Sys)); err != nil {
+		return err
+	}
+
+	if err = d.Set("archived", status == entityStatusArchived); err != nil {
+		return err
+	}
+
+	if err = d.Set("fields", flattenAssetFields(asset, d.Get("locale").(string), d.Get("fields").([]interface{}))); err != nil {
+		return err
+	}
+
 	return err
 }
+
+// flattenAssetFields converts the fields of an asset into the fields block. The title and the description keep
+// the order of current like flattenEntryFields, and upload is taken from current, because Contentful drops the
+// upload URL once the file is processed.
+func flattenAssetFields(asset *contentful.Asset, locale string, current []interface{}) []interface{} {
+	var currentFields map[string]interface{}
+	if len(current) > 0 {
+		currentFields, _ = current[0].(map[string]interface{})
+	}
+	currentList := func(key string) []interface{} {
+		list, _ := currentFields[key].([]interface{})
+		return list
+	}
+
+	fields := asset.Fields
+	if fields == nil {
+		fields = &contentful.AssetFields{}
+	}
+
+	var currentFile map[string]interface{}
+	if files, ok := currentFields["file"].(*schema.Set); ok && files.Len() > 0 {
+		currentFile, _ = files.List()[0].(map[string]interface{})
+	}
+	var files []interface{}
+	if f := fields.File[locale]; f != nil {
+		file := map[string]interface{}{
+			"url":          f.URL,
+			"file_name":    f.FileName,
+			"content_type": f.ContentType,
+			"upload":       currentFile["upload"],
+			"details":      []interface{}{},
+		}
+		if file["upload"] == nil {
+			file["upload"] = ""
+		}
+		// A name which is not configured stays empty, because it would differ from the configuration in every plan.
+		if currentFile != nil && currentFile["file_name"] == "" {
+			file["file_name"] = ""
+		}
+		if f.Details != nil {
+			image := []interface{}{}
+			if f.Details.Image != nil {
+				image = append(image, map[string]interface{}{"width": f.Details.Image.Width, "height": f.Details.Image.Height})
+			}
+			file["details"] = []interface{}{map[string]interface{}{"size": f.Details.Size, "image": image}}
+		}
+		files = append(files, file)
+	}
+
+	return []interface{}{map[string]interface{}{
+		"title":       flattenLocalizedContents(fields.Title, currentList("title")),
+		"description": flattenLocalizedContents(fields.Description, currentList("description")),
+		"file":        files,
+	}}
+}
+
+// flattenLocalizedContents converts values by locale into blocks of content and locale. The blocks of current
+// keep their order, and the other values follow ordered by locale.
+func flattenLocalizedContents(values map[string]string, current []interface{}) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, raw := range current {
+		block, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		locale, _ := block["locale"].(string)
+		if content, ok := values[locale]; ok && !seen[locale] {
+			result = append(result, map[string]interface{}{"locale": locale, "content": content})
+			seen[locale] = true
+		}
+	}
+
+	locales := make([]string, 0, len(values))
+	for locale := range values {
+		if !seen[locale] {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Strings(locales)
+	for _, locale := range locales {
+		result = append(result, map[string]interface{}{"locale": locale, "content": values[locale]})
+	}
+	return result
+}
+
+// hashAssetFile hashes the configurable arguments of a file block, so that the refreshed URL and details do not
+// make the configured file a different element of the set, while a renamed file does.
+func hashAssetFile(v interface{}) int {
+	file := v.(map[string]interface{})
+	return schema.HashString(fmt.Sprintf("%s\t%s\t%s", file["content_type"], file["upload"], file["file_name"]))
+}
+
+// isAssetFileUploaded returns whether the file block has a source to process the file from.
+func isAssetFileUploaded(file map[string]interface{}) bool {
+	upload, _ := file["upload"].(string)
+	return upload != ""
+}
@@ -0,0 +1,113 @@
+package contentful
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestFlattenLocalizedContents(t *testing.T) {
+	values := map[string]string{"en-US": "Logo", "de": "Logo (de)", "fr": "Logo (fr)"}
+	current := []interface{}{
+		map[string]interface{}{"locale": "fr", "content": "Old"},
+		map[string]interface{}{"locale": "ja", "content": "Removed"},
+	}
+
+	expect := []interface{}{
+		map[string]interface{}{"locale": "fr", "content": "Logo (fr)"},
+		map[string]interface{}{"locale": "de", "content": "Logo (de)"},
+		map[string]interface{}{"locale": "en-US", "content": "Logo"},
+	}
+	if diff := cmp.Diff(expect, flattenLocalizedContents(values, current)); diff != "" {
+		t.Errorf("flattenLocalizedContents result diff (-expect, +got)\n%s", diff)
+	}
+}
+
+func TestResourceReadAsset_fieldsDrift(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeCMAEnvironment(t)
+
+	r := resourceContentfulAsset()
+	config := map[string]interface{}{
+		"space_id":  "space",
+		"asset_id":  "logo",
+		"locale":    "en-US",
+		"published": true,
+		"archived":  false,
+		"fields": []interface{}{map[string]interface{}{
+			"title":       []interface{}{map[string]interface{}{"locale": "en-US", "content": "Logo"}},
+			"description": []interface{}{map[string]interface{}{"locale": "en-US", "content": "The logo"}},
+			"file": []interface{}{map[string]interface{}{
+				"upload":       "https://example.com/logo.png",
+				"file_name":    "logo.png",
+				"content_type": "image/png",
+			}},
+		}},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := resourceCreateAsset(ctx, d, client.Assets); diags.HasError() {
+		t.Fatalf("resourceCreateAsset() diags = %v", diags)
+	}
+
+	// The URL and the details of the processed file do not differ from the configuration.
+	diff, err := r.SimpleDiff(ctx, d.State(), terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && len(diff.Attributes) > 0 {
+		t.Errorf("the configuration should have no diff after create, got %v", diff.Attributes)
+	}
+
+	// An editor changes the title, adds a description and renames the file in the web app.
+	asset, err := client.Assets.Get(ctx, "space", "logo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	asset.Fields.Title["en-US"] = "Edited"
+	asset.Fields.Description["de"] = "Das Logo"
+	asset.Fields.File["en-US"].FileName = "renamed.png"
+	if err := client.Assets.Upsert(ctx, "space", asset); err != nil {
+		t.Fatal(err)
+	}
+
+	if diags := resourceReadAsset(ctx, d, client.Assets); diags.HasError() {
+		t.Fatalf("resourceReadAsset() diags = %v", diags)
+	}
+	fields := d.Get("fields").([]interface{})[0].(map[string]interface{})
+	expectTitle := []interface{}{map[string]interface{}{"locale": "en-US", "content": "Edited"}}
+	if diff := cmp.Diff(expectTitle, fields["title"]); diff != "" {
+		t.Errorf("title after read diff (-expect, +got)\n%s", diff)
+	}
+	expectDescription := []interface{}{
+		map[string]interface{}{"locale": "en-US", "content": "The logo"},
+		map[string]interface{}{"locale": "de", "content": "Das Logo"},
+	}
+	if diff := cmp.Diff(expectDescription, fields["description"]); diff != "" {
+		t.Errorf("description after read diff (-expect, +got)\n%s", diff)
+	}
+	file := fields["file"].(*schema.Set).List()[0].(map[string]interface{})
+	if file["file_name"] != "renamed.png" || file["upload"] != "https://example.com/logo.png" || file["url"] == "" {
+		t.Errorf("file after read should have the new name, the configured upload and the URL, got %v", file)
+	}
+
+	diff, err = r.SimpleDiff(ctx, d.State(), terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Attributes["fields.0.title.0.content"] == nil {
+		t.Fatalf("the changed title should have a diff, got %v", diff)
+	}
+	var fileNameChanged bool
+	for k, attr := range diff.Attributes {
+		if strings.HasSuffix(k, ".file_name") && attr.New == "logo.png" {
+			fileNameChanged = true
+		}
+	}
+	if !fileNameChanged {
+		t.Errorf("the renamed file should have a diff, got %v", diff.Attributes)
+	}
+}
@@ -0,0 +1,210 @@
+package contentful
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// bulkActionPollInterval is the interval to check whether a bulk action has completed.
+var bulkActionPollInterval = 2 * time.Second
+
+func resourceContentfulBulkAction() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Publishes, unpublishes or validates entries and assets in bulk whenever the resource is created or updated. More than 200 entities are processed in several bulk actions",
+		CreateContext: wrapBulkAction(resourceCreateBulkAction),
+		ReadContext:   wrapBulkAction(resourceReadBulkAction),
+		UpdateContext: wrapBulkAction(resourceUpdateBulkAction),
+		DeleteContext: wrapBulkAction(resourceDeleteBulkAction),
+		CustomizeDiff: setProviderDefaults,
+
+		Schema: map[string]*schema.Schema{
+			"space_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the space. Defaults to `space_id` of the provider",
+			},
+			"env_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the environment. Defaults to `environment_id` of the provider",
+			},
+			"action": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"publish", "unpublish", "validate"}, false)),
+				Description:      "The action to run: `publish`, `unpublish` or `validate`",
+			},
+			"entries": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: []string{"entries", "assets"},
+				Description:  "The IDs of the entries to process",
+			},
+			"assets": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: []string{"entries", "assets"},
+				Description:  "The IDs of the assets to process",
+			},
+			"bulk_action_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the bulk actions run by the last create or update",
+			},
+		},
+	}
+}
+
+func wrapBulkAction(f func(ctx context.Context, d *schema.ResourceData, client ContentfulBulkActionClient) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+		client := m.(*providerMeta).client
+		return f(ctx, d, &bulkActionsService{c: newCMAClient(client)})
+	}
+}
+
+func resourceCreateBulkAction(ctx context.Context, d *schema.ResourceData, client ContentfulBulkActionClient) (diags diag.Diagnostics) {
+	d.SetId(resource.UniqueId())
+
+	return runBulkAction(ctx, d, client)
+}
+
+// resourceReadBulkAction does nothing, because bulk actions are executed once and have no state to refresh.
+func resourceReadBulkAction(ctx context.Context, d *schema.ResourceData, client ContentfulBulkActionClient) (diags diag.Diagnostics) {
+	return nil
+}
+
+func resourceUpdateBulkAction(ctx context.Context, d *schema.ResourceData, client ContentfulBulkActionClient) (diags diag.Diagnostics) {
+	defer func() {
+		if diags.HasError() {
+			d.Partial(true)
+		}
+	}()
+
+	return runBulkAction(ctx, d, client)
+}
+
+// resourceDeleteBulkAction only removes the resource from the state. Published entities stay published.
+func resourceDeleteBulkAction(ctx context.Context, d *schema.ResourceData, client ContentfulBulkActionClient) (diags diag.Diagnostics) {
+	return nil
+}
+
+// runBulkAction executes the action of the resource and records the IDs of the bulk actions.
+func runBulkAction(ctx context.Context, d *schema.ResourceData, client ContentfulBulkActionClient) (diags diag.Diagnostics) {
+	links := newEntityLinks(toStrings(d.Get("entries").([]interface{})), toStrings(d.Get("assets").([]interface{})))
+
+	bulkActionIDs, diags := runBulkActions(ctx, client, d.Get("space_id").(string), d.Get("env_id").(string), d.Get("action").(string), links)
+
+	if err := d.Set("bulk_action_ids", bulkActionIDs); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+	}
+	return
+}
+
+// runBulkActions executes the action in batches of bulkActionMaxItems entities and waits until each batch completes.
+// It returns the IDs of the bulk actions which were started.
+func runBulkActions(ctx context.Context, client ContentfulBulkActionClient, spaceID, envID, action string, links EntityLinks) (bulkActionIDs []string, diags diag.Diagnostics) {
+	bulkActionIDs = make([]string, 0)
+	for _, batch := range chunkEntityLinks(links, bulkActionMaxItems) {
+		var bulkAction *BulkAction
+		var err error
+		switch action {
+		case "publish":
+			if batchDiags := setEntityVersions(ctx, client, spaceID, envID, batch); batchDiags.HasError() {
+				diags = append(diags, batchDiags...)
+				continue
+			}
+			bulkAction, err = client.Publish(ctx, spaceID, envID, batch)
+		case "unpublish":
+			bulkAction, err = client.Unpublish(ctx, spaceID, envID, batch)
+		case "validate":
+			bulkAction, err = client.Validate(ctx, spaceID, envID, batch)
+		}
+		if err != nil {
+			diags = append(diags, contentfulErrorToDiagnostic(err)...)
+			continue
+		}
+
+		bulkActionIDs = append(bulkActionIDs, bulkAction.Sys.ID)
+
+		err = pollUntil(ctx, bulkActionPollInterval, func() (bool, error) {
+			if bulkAction.Sys.Status == "succeeded" || bulkAction.Sys.Status == "failed" {
+				return true, nil
+			}
+
+			bulkAction, err = client.GetAction(ctx, spaceID, envID, bulkAction.Sys.ID)
+			return false, err
+		})
+		if err != nil {
+			diags = append(diags, contentfulErrorToDiagnostic(err)...)
+			continue
+		}
+
+		if bulkAction.Sys.Status == "failed" {
+			if bulkAction.Error == nil {
+				bulkAction.Error = &ActionError{Message: fmt.Sprintf("bulk action %s failed", bulkAction.Sys.ID)}
+			}
+			diags = append(diags, actionErrorToDiagnostic(bulkAction.Error)...)
+		}
+	}
+	return
+}
+
+// setEntityVersions sets the current version to each link, which the API requires to publish entities.
+func setEntityVersions(ctx context.Context, client ContentfulBulkActionClient, spaceID, envID string, links EntityLinks) (diags diag.Diagnostics) {
+	ids := map[string][]string{}
+	for _, item := range links.Items {
+		ids[item.Sys.LinkType] = append(ids[item.Sys.LinkType], item.Sys.ID)
+	}
+
+	versions := map[string]map[string]int{}
+	for linkType, linkIDs := range ids {
+		v, err := client.Versions(ctx, spaceID, envID, linkType, linkIDs)
+		if err != nil {
+			diags = append(diags, contentfulErrorToDiagnostic(err)...)
+			return
+		}
+		versions[linkType] = v
+	}
+
+	for i, item := range links.Items {
+		version, ok := versions[item.Sys.LinkType][item.Sys.ID]
+		if !ok {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("%s %s: the requested resource can not be found", item.Sys.LinkType, item.Sys.ID),
+			})
+			continue
+		}
+		links.Items[i].Sys.Version = version
+	}
+	return
+}
+
+// chunkEntityLinks splits links into batches with at most size items.
+func chunkEntityLinks(links EntityLinks, size int) []EntityLinks {
+	chunks := make([]EntityLinks, 0, (len(links.Items)+size-1)/size)
+	for start := 0; start < len(links.Items); start += size {
+		end := start + size
+		if end > len(links.Items) {
+			end = len(links.Items)
+		}
+		chunks = append(chunks, EntityLinks{
+			Sys:   links.Sys,
+			Items: links.Items[start:end],
+		})
+	}
+	return chunks
+}
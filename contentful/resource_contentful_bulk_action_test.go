@@ -0,0 +1,52 @@
+package contentful
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+func TestChunkEntityLinks(t *testing.T) {
+	entryIDs := make([]string, 5)
+	for i := range entryIDs {
+		entryIDs[i] = fmt.Sprintf("entry%d", i)
+	}
+
+	tests := map[string]struct {
+		links EntityLinks
+		size  int
+
+		expect []EntityLinks
+	}{
+		"empty links should return no chunk": {
+			links:  newEntityLinks(nil, nil),
+			size:   2,
+			expect: []EntityLinks{},
+		},
+		"links should be split by size": {
+			links: newEntityLinks(entryIDs, []string{"asset0"}),
+			size:  4,
+			expect: []EntityLinks{
+				newEntityLinks(entryIDs[:4], nil),
+				{
+					Sys: contentful.Sys{Type: "Array"},
+					Items: []contentful.Entity{
+						{Sys: contentful.Sys{Type: "Link", LinkType: "Entry", ID: "entry4"}},
+						{Sys: contentful.Sys{Type: "Link", LinkType: "Asset", ID: "asset0"}},
+					},
+				},
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			got := chunkEntityLinks(tt.links, tt.size)
+			if diff := cmp.Diff(tt.expect, got); diff != "" {
+				t.Errorf("chunkEntityLinks result diff (-expect, +got)\n%s", diff)
+			}
+		})
+	}
+}
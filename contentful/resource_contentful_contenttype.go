@@ -2,71 +2,163 @@ package contentful
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	contentful "github.com/kitagry/contentful-go"
 )
 
 func resourceContentfulContentType() *schema.Resource {
 	return &schema.Resource{
+		Description:   "Manages a content type of an environment. The content type is activated whenever it is changed",
 		CreateContext: wrapContentType(resourceContentTypeCreate),
 		ReadContext:   wrapContentType(resourceContentTypeRead),
 		UpdateContext: wrapContentType(resourceContentTypeUpdate),
 		DeleteContext: wrapContentType(resourceContentTypeDelete),
+		Importer:      importEnvironmentEntity("content_type_id"),
+		CustomizeDiff: customdiff.All(setProviderDefaults, checkDestructiveFieldChanges),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"space_id": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the space. Defaults to `space_id` of the provider",
 			},
 			"version": {
-				Type:     schema.TypeInt,
-				Computed: true,
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The current version of the content type, which increases on every change",
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the content type",
 			},
 			"description": {
-				Type:     schema.TypeString,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the content type",
+			},
+			"annotations": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the annotations of the content type, such as `Contentful:AggregateRoot`",
+			},
+			"taxonomy": {
+				Type:     schema.TypeList,
 				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "TaxonomyConceptScheme",
+							Description: "The type of the taxonomy: `TaxonomyConceptScheme` or `TaxonomyConcept`",
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the concept scheme or the concept",
+						},
+						"required": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether entries must be tagged with a concept of the taxonomy to be published",
+						},
+					},
+				},
+				Description: "The taxonomy concepts which entries of the content type may be tagged with",
+			},
+			"delete_entries_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether destroying the content type unpublishes and deletes its entries first. Otherwise destroying fails while entries of the content type exist",
+			},
+			"allow_destructive_changes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether changes which delete the content of fields, such as changing the type of a field, may be applied. Otherwise planning them fails",
 			},
 			"display_field": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the field shown as the title of entries",
 			},
 			"content_type_id": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ID of the content type. Generated by Contentful when omitted",
 			},
 			"env_id": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the environment. Defaults to `environment_id` of the provider",
+			},
+			"definition_json": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ExactlyOneOf:     []string{"field", "definition_json"},
+				ValidateDiagFunc: validation.ToDiagFunc(validateContentTypeDefinition),
+				DiffSuppressFunc: suppressEquivalentContentTypeDefinition,
+				Description:      "The fields of the content type as the JSON of a Contentful content type, such as an export of it. Only `fields` is used, and it is compared by its meaning rather than its text. Field IDs are the IDs Contentful stores the fields by, with `apiName` for renamed fields. Conflicts with `field`",
 			},
 			"field": {
-				Type:     schema.TypeList,
-				Required: true,
+				Type:         schema.TypeList,
+				Optional:     true,
+				ExactlyOneOf: []string{"field", "definition_json"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"id": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the field, which entries use. Contentful calls it `apiName` once the field is renamed",
+						},
+						"internal_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID Contentful stores the field by. It stays the first `id` of the field when the field is renamed",
+						},
+						"rename_from": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The previous ID of the field. Changing `id` renames the field from this ID and keeps its content, instead of deleting the field and creating a new one",
 						},
 						"name": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the field",
 						},
 						"type": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The type of the field, such as `Symbol`, `Text`, `Integer`, `Link`, `ResourceLink` or `Array`",
 						},
 						"link_type": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The type of the linked entity, `Entry` or `Asset`, when `type` is `Link`",
 						},
 						"items": {
 							Type:     schema.TypeList,
@@ -75,72 +167,134 @@ func resourceContentfulContentType() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"type": {
-										Type:     schema.TypeString,
-										Required: true,
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The type of the items: `Symbol`, `Link` or `ResourceLink`",
 									},
 									"link_type": {
-										Type:     schema.TypeString,
-										Optional: true,
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The type of the linked items, `Entry` or `Asset`, when `type` is `Link`",
 									},
 									"validations": {
-										Type:     schema.TypeList,
-										Optional: true,
-										Elem:     &schema.Schema{Type: schema.TypeString},
+										Type:        schema.TypeList,
+										Optional:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Description: "The validations of the items, as JSON strings",
 									},
 								},
 							},
+							Description: "The type of the items of an `Array` field",
 						},
 						"required": {
-							Type:     schema.TypeBool,
-							Optional: true,
-							Default:  false,
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the field must have a value to publish an entry",
 						},
 						"localized": {
-							Type:     schema.TypeBool,
-							Optional: true,
-							Default:  false,
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the field has a value in each locale",
 						},
 						"disabled": {
-							Type:     schema.TypeBool,
-							Optional: true,
-							Default:  false,
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether editing the field is disabled in the web app",
 						},
 						"omitted": {
-							Type:     schema.TypeBool,
-							Optional: true,
-							Default:  false,
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the field is omitted from responses of the Content Delivery API",
 						},
 						"validations": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The validations of the field, as JSON strings",
+						},
+						"annotations": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The IDs of the annotations of the field, such as `Contentful:AggregateComponent`",
+						},
+						"default_value": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The value of the field in new entries, by locale code. Values of `Boolean`, `Integer` and `Number` fields are written as strings, such as `\"true\"` or `\"10\"`",
+						},
+						"allowed_resources": {
 							Type:     schema.TypeList,
 							Optional: true,
-							Elem:     &schema.Schema{Type: schema.TypeString},
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Default:     "Contentful:Entry",
+										Description: "The type of the resources",
+									},
+									"source": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The CRN of the space of the resources, such as `crn:contentful:::content:spaces/<space_id>`",
+									},
+									"content_types": {
+										Type:        schema.TypeList,
+										Required:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Description: "The IDs of the content types of the entries",
+									},
+								},
+							},
+							Description: "The resources which a `ResourceLink` field, or an `Array` of `ResourceLink` items, may link to. They can be in other spaces",
 						},
 					},
 				},
+				Description: "The fields of the content type, in the order shown in the web app. Conflicts with `definition_json`",
 			},
 		},
 	}
 }
 
-func wrapContentType(f func(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, apiKey ContentfulContentTypeClient) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+// contentTypeEntriesClient lists and deletes the entries of a content type.
+type contentTypeEntriesClient interface {
+	ContentfulEntryClient
+	ContentfulEntryListClient
+}
+
+func newContentTypeEntriesClient(client *contentful.Client) contentTypeEntriesClient {
+	return struct {
+		ContentfulEntryClient
+		ContentfulEntryListClient
+	}{client.Entries, &entriesService{c: newCMAClient(client)}}
+}
+
+func wrapContentType(f func(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, apiKey ContentfulContentTypeClient, entries contentTypeEntriesClient) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-		client := m.(*contentful.Client)
+		meta := m.(*providerMeta)
+		client := meta.client
 		spaceID := d.Get("space_id").(string)
 		envID := d.Get("env_id").(string)
-		env, err := client.Environments.Get(ctx, spaceID, envID)
+		env, err := meta.environments.Get(ctx, client.Environments, spaceID, envID)
 		if err != nil {
 			diags = append(diags, contentfulErrorToDiagnostic(err)...)
 			return
 		}
-		return f(ctx, d, env, client.ContentTypes)
+		return f(ctx, d, env, &contentTypesService{c: newCMAClient(client)}, newContentTypeEntriesClient(client))
 	}
 }
 
-func resourceContentTypeCreate(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulContentTypeClient) (diags diag.Diagnostics) {
-	ct := &contentful.ContentType{
+func resourceContentTypeCreate(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulContentTypeClient, entries contentTypeEntriesClient) (diags diag.Diagnostics) {
+	ct := &ContentType{
 		Name:         d.Get("name").(string),
 		DisplayField: d.Get("display_field").(string),
-		Fields:       []*contentful.Field{},
+		Fields:       []*ContentTypeField{},
 		Sys: &contentful.Sys{
 			ID: d.Get("content_type_id").(string),
 		},
@@ -158,10 +312,11 @@ func resourceContentTypeCreate(ctx context.Context, d *schema.ResourceData, env
 		ct.Description = description.(string)
 	}
 
-	ct.Fields, diags = newFields(d.Get("field").([]interface{}))
+	ct.Fields, diags = expandContentTypeFields(d.Get("field").([]interface{}), d.Get("definition_json").(string), nil)
 	if diags.HasError() {
 		return
 	}
+	ct.Metadata = newContentTypeMetadata(d, ct.Fields)
 
 	if err := upsertAndActivate(ctx, client, env, ct); err != nil {
 		diags = append(diags, contentfulErrorToDiagnostic(err)...)
@@ -178,22 +333,32 @@ func resourceContentTypeCreate(ctx context.Context, d *schema.ResourceData, env
 	return nil
 }
 
-func resourceContentTypeRead(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulContentTypeClient) (diags diag.Diagnostics) {
-	_, err := client.Get(ctx, env, d.Id())
+func resourceContentTypeRead(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulContentTypeClient, entries contentTypeEntriesClient) (diags diag.Diagnostics) {
+	ct, err := client.Get(ctx, env, d.Id())
 	if err != nil {
 		diags = append(diags, contentfulErrorToDiagnostic(err)...)
 		return
 	}
+
+	if err = setContentTypeProperties(d, ct); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
 	return
 }
 
-func resourceContentTypeUpdate(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulContentTypeClient) (diags diag.Diagnostics) {
+func resourceContentTypeUpdate(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulContentTypeClient, entries contentTypeEntriesClient) (diags diag.Diagnostics) {
 	defer func() {
 		if diags.HasError() {
 			d.Partial(true)
 		}
 	}()
 
+	// delete_entries_on_destroy and allow_destructive_changes only control how the content type is changed.
+	if !d.HasChangesExcept("delete_entries_on_destroy", "allow_destructive_changes") {
+		return
+	}
+
 	ct, err := client.Get(ctx, env, d.Id())
 	if err != nil {
 		diags = append(diags, contentfulErrorToDiagnostic(err)...)
@@ -201,18 +366,28 @@ func resourceContentTypeUpdate(ctx context.Context, d *schema.ResourceData, env
 	}
 
 	ct.Name = d.Get("name").(string)
-	ct.DisplayField = d.Get("display_field").(string)
 
 	if description, ok := d.GetOk("description"); ok {
 		ct.Description = description.(string)
 	}
 
-	if d.HasChange("field") {
-		old, nw := d.GetChange("field")
+	// Renamed fields are known to Contentful by their first ID, so the fields are sent with the current IDs.
+	currentFields := ct.Fields
+	fields, fieldDiags := expandContentTypeFields(d.Get("field").([]interface{}), d.Get("definition_json").(string), currentFields)
+	if fieldDiags.HasError() {
+		diags = append(diags, fieldDiags...)
+		return
+	}
+
+	if d.HasChanges("field", "definition_json") {
+		oldRawFields, _ := d.GetChange("field")
+		oldDefinition, _ := d.GetChange("definition_json")
+		oldFields, _ := expandContentTypeFields(oldRawFields.([]interface{}), oldDefinition.(string), currentFields)
 
-		firstApplyFields, secondApplyFields, shouldSecondApply := checkFieldsToOmit(old.([]interface{}), nw.([]interface{}))
+		firstApplyFields, secondApplyFields, shouldSecondApply := checkFieldsToOmit(oldFields, fields)
 
 		ct.Fields = firstApplyFields
+		ct.Metadata = withoutDeletedFieldAnnotations(ct.Metadata, ct.Fields)
 		// To remove a field from a content type 4 API calls need to be made.
 		// Omit the removed fields and publish the new version of the content type,
 		// followed by the field removal and final publish.
@@ -223,16 +398,34 @@ func resourceContentTypeUpdate(ctx context.Context, d *schema.ResourceData, env
 
 		if shouldSecondApply {
 			ct.Fields = secondApplyFields
+			ct.Metadata = withoutDeletedFieldAnnotations(ct.Metadata, ct.Fields)
+			// The display field is set again with the final fields when it is deleted to change its type.
+			if _, ok := findField(ct.Fields, ct.DisplayField); !ok {
+				ct.DisplayField = ""
+			}
 			if err = upsertAndActivate(ctx, client, env, ct); err != nil {
 				diags = append(diags, contentfulErrorToDiagnostic(err)...)
 				return
 			}
 		}
+
+		for _, id := range destructiveFieldChanges(oldFields, fields) {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("content of field %s was deleted", id),
+				Detail:   "The type of the field changed, so the field was deleted from all entries and created again.",
+			})
+		}
 	}
 
-	ct.Fields, diags = newFields(d.Get("field").([]interface{}))
-	if diags.HasError() {
-		return
+	ct.Fields = fields
+	ct.Metadata = newContentTypeMetadata(d, ct.Fields)
+	// The display field may be added or renamed by this change, so it is set only with the final fields.
+	ct.DisplayField = d.Get("display_field").(string)
+	for _, field := range ct.Fields {
+		if field.APIName == ct.DisplayField {
+			ct.DisplayField = field.ID
+		}
 	}
 	if err = upsertAndActivate(ctx, client, env, ct); err != nil {
 		diags = append(diags, contentfulErrorToDiagnostic(err)...)
@@ -247,7 +440,7 @@ func resourceContentTypeUpdate(ctx context.Context, d *schema.ResourceData, env
 	return
 }
 
-func upsertAndActivate(ctx context.Context, client ContentfulContentTypeClient, env *contentful.Environment, ct *contentful.ContentType) error {
+func upsertAndActivate(ctx context.Context, client ContentfulContentTypeClient, env *contentful.Environment, ct *ContentType) error {
 	if err := client.Upsert(ctx, env, ct); err != nil {
 		return err
 	}
@@ -258,7 +451,62 @@ func upsertAndActivate(ctx context.Context, client ContentfulContentTypeClient,
 	return nil
 }
 
-func resourceContentTypeDelete(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulContentTypeClient) (diags diag.Diagnostics) {
+// contentTypeEntriesPageSize is the number of entries read at once to delete the entries of a content type.
+const contentTypeEntriesPageSize = 100
+
+// deleteContentTypeEntries unpublishes and deletes every entry of the content type.
+// It always reads the first page, because deleting entries moves the following ones forward.
+func deleteContentTypeEntries(ctx context.Context, client contentTypeEntriesClient, env *contentful.Environment, spaceID, envID, contentTypeID string) error {
+	for {
+		col, err := client.ListByContentType(ctx, spaceID, envID, contentTypeID, 0, contentTypeEntriesPageSize)
+		if err != nil {
+			return err
+		}
+		if len(col.Items) == 0 {
+			return nil
+		}
+
+		for _, entry := range col.Items {
+			switch entityStatus(entry.Sys) {
+			case entityStatusPublished, entityStatusChanged:
+				if err := client.Unpublish(ctx, env, entry); err != nil {
+					return fmt.Errorf("failed to unpublish entry %s: %w", entry.Sys.ID, err)
+				}
+			}
+			if err := client.Delete(ctx, env, entry.Sys.ID); err != nil {
+				return fmt.Errorf("failed to delete entry %s: %w", entry.Sys.ID, err)
+			}
+		}
+	}
+}
+
+func resourceContentTypeDelete(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulContentTypeClient, entries contentTypeEntriesClient) (diags diag.Diagnostics) {
+	spaceID := d.Get("space_id").(string)
+	envID := d.Get("env_id").(string)
+
+	// Contentful does not delete content types with entries. The entries are handled before deactivating
+	// the content type, so that the content type stays usable when it cannot be deleted.
+	if d.Get("delete_entries_on_destroy").(bool) {
+		if err := deleteContentTypeEntries(ctx, entries, env, spaceID, envID, d.Id()); err != nil {
+			diags = append(diags, contentfulErrorToDiagnostic(err)...)
+			return
+		}
+	} else {
+		col, err := entries.ListByContentType(ctx, spaceID, envID, d.Id(), 0, 1)
+		if err != nil {
+			diags = append(diags, contentfulErrorToDiagnostic(err)...)
+			return
+		}
+		if col.Total > 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("content type %s cannot be deleted, because %d entries of it exist", d.Id(), col.Total),
+				Detail:   "Delete the entries first, or set delete_entries_on_destroy to delete them together with the content type.",
+			})
+			return
+		}
+	}
+
 	ct, err := client.Get(ctx, env, d.Id())
 	if err != nil {
 		diags = append(diags, contentfulErrorToDiagnostic(err)...)
@@ -278,41 +526,157 @@ func resourceContentTypeDelete(ctx context.Context, d *schema.ResourceData, env
 	return
 }
 
-func setContentTypeProperties(d *schema.ResourceData, ct *contentful.ContentType) (err error) {
+func setContentTypeProperties(d *schema.ResourceData, ct *ContentType) (err error) {
 	if err = d.Set("version", ct.Sys.Version); err != nil {
 		return err
 	}
 
+	var annotations []contentful.Entity
+	var taxonomy []*TaxonomyValidation
+	if ct.Metadata != nil {
+		if ct.Metadata.Annotations != nil {
+			annotations = ct.Metadata.Annotations.ContentType
+		}
+		taxonomy = ct.Metadata.Taxonomy
+	}
+	if err = d.Set("annotations", flattenAnnotationLinks(annotations)); err != nil {
+		return err
+	}
+	if err = d.Set("taxonomy", flattenTaxonomy(taxonomy)); err != nil {
+		return err
+	}
+
+	if err = d.Set("field", setFieldProperties(d.Get("field").([]interface{}), ct)); err != nil {
+		return err
+	}
+
+	if d.Get("definition_json").(string) != "" {
+		definition, err := newContentTypeDefinition(ct.Fields)
+		if err != nil {
+			return err
+		}
+		if err = d.Set("definition_json", definition); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-// Contentful API should omit the field.
-// And if user want to change field type, user should delete the field completely before user create new field type field.
-func checkFieldsToOmit(oldFields, newFields []interface{}) (firstApplyFields, secondApplyFields []*contentful.Field, shouldSecondApply bool) {
-	getFieldFromID := func(fields []interface{}, id string) (map[string]interface{}, bool) {
-		for _, field := range fields {
-			castedField := field.(map[string]interface{})
-			if castedField["id"].(string) == id {
-				return castedField, true
-			}
+// setFieldProperties sets the properties of the fields which Contentful fills in to the configured fields.
+// The other properties are kept as configured.
+func setFieldProperties(fields []interface{}, ct *ContentType) []interface{} {
+	byAPIName := make(map[string]*ContentTypeField, len(ct.Fields))
+	for _, field := range ct.Fields {
+		byAPIName[apiNameOf(field)] = field
+	}
+	var annotations map[string][]contentful.Entity
+	if ct.Metadata != nil && ct.Metadata.Annotations != nil {
+		annotations = ct.Metadata.Annotations.ContentTypeField
+	}
+
+	for _, field := range fields {
+		fieldMap := field.(map[string]interface{})
+		ctField, ok := byAPIName[fieldMap["id"].(string)]
+		if !ok {
+			continue
+		}
+
+		fieldMap["internal_id"] = ctField.ID
+		fieldMap["default_value"] = flattenDefaultValue(ctField.DefaultValue)
+		fieldMap["allowed_resources"] = flattenAllowedResources(ctField.AllowedResources)
+		fieldMap["annotations"] = flattenAnnotationLinks(annotations[ctField.ID])
+	}
+	return fields
+}
+
+// newContentTypeMetadata builds the annotations and the taxonomy of the content type. fields are the fields
+// built from the field blocks, in the same order, so that the annotations of fields are keyed by their current IDs.
+func newContentTypeMetadata(d *schema.ResourceData, fields []*ContentTypeField) *ContentTypeMetadata {
+	annotations := &ContentTypeAnnotations{
+		ContentType:      newAnnotationLinks(toStrings(d.Get("annotations").([]interface{}))),
+		ContentTypeField: map[string][]contentful.Entity{},
+	}
+	for i, field := range d.Get("field").([]interface{}) {
+		ids := toStrings(field.(map[string]interface{})["annotations"].([]interface{}))
+		if len(ids) > 0 && i < len(fields) {
+			annotations.ContentTypeField[fields[i].ID] = newAnnotationLinks(ids)
+		}
+	}
+
+	var taxonomy []*TaxonomyValidation
+	for _, v := range d.Get("taxonomy").([]interface{}) {
+		t := v.(map[string]interface{})
+		taxonomy = append(taxonomy, &TaxonomyValidation{
+			Sys:      contentful.Sys{Type: "Link", LinkType: t["type"].(string), ID: t["id"].(string)},
+			Required: t["required"].(bool),
+		})
+	}
+
+	if len(annotations.ContentType) == 0 && len(annotations.ContentTypeField) == 0 && len(taxonomy) == 0 {
+		return nil
+	}
+	return &ContentTypeMetadata{Annotations: annotations, Taxonomy: taxonomy}
+}
+
+// withoutDeletedFieldAnnotations drops the annotations of fields which are not in fields, because Contentful
+// does not annotate fields which do not exist.
+func withoutDeletedFieldAnnotations(metadata *ContentTypeMetadata, fields []*ContentTypeField) *ContentTypeMetadata {
+	if metadata == nil || metadata.Annotations == nil {
+		return metadata
+	}
+
+	fieldAnnotations := make(map[string][]contentful.Entity)
+	for _, field := range fields {
+		if links, ok := metadata.Annotations.ContentTypeField[field.ID]; ok {
+			fieldAnnotations[field.ID] = links
 		}
-		return nil, false
 	}
+	return &ContentTypeMetadata{
+		Annotations: &ContentTypeAnnotations{
+			ContentType:      metadata.Annotations.ContentType,
+			ContentTypeField: fieldAnnotations,
+		},
+		Taxonomy: metadata.Taxonomy,
+	}
+}
+
+func flattenAnnotationLinks(links []contentful.Entity) []interface{} {
+	result := make([]interface{}, 0, len(links))
+	for _, link := range links {
+		result = append(result, link.Sys.ID)
+	}
+	return result
+}
 
-	for i := 0; i < len(oldFields); i++ {
-		oldFieldMap := oldFields[i].(map[string]interface{})
+func flattenTaxonomy(taxonomy []*TaxonomyValidation) []interface{} {
+	result := make([]interface{}, 0, len(taxonomy))
+	for _, t := range taxonomy {
+		result = append(result, map[string]interface{}{
+			"type":     t.Sys.LinkType,
+			"id":       t.Sys.ID,
+			"required": t.Required,
+		})
+	}
+	return result
+}
 
-		newFieldMap, ok := getFieldFromID(newFields, oldFieldMap["id"].(string))
+// Contentful API should omit the field.
+// And if user want to change field type, user should delete the field completely before user create new field type field.
+// The fields are matched by the IDs Contentful knows them by, so renamed fields are kept.
+func checkFieldsToOmit(oldFields, newFields []*ContentTypeField) (firstApplyFields, secondApplyFields []*ContentTypeField, shouldSecondApply bool) {
+	for _, oldField := range oldFields {
+		newField, ok := findField(newFields, oldField.ID)
 
 		toOmitted := false
 		if !ok {
 			// field was deleted
 			toOmitted = true
 		} else {
-			if oldFieldMap["type"].(string) != newFieldMap["type"].(string) {
+			if oldField.Type != newField.Type {
 				toOmitted = true
 			}
-			if oldFieldMap["required"].(bool) != newFieldMap["required"].(bool) {
+			if oldField.Required != newField.Required {
 				toOmitted = true
 			}
 		}
@@ -320,19 +684,19 @@ func checkFieldsToOmit(oldFields, newFields []interface{}) (firstApplyFields, se
 		shouldDelete := false
 		if ok {
 			// if field type is changed, should delete field completely
-			if oldFieldMap["type"].(string) != newFieldMap["type"].(string) {
+			if oldField.Type != newField.Type {
 				shouldDelete = true
 			}
 		}
 
-		field, _ := newField(oldFieldMap, i)
+		field := *oldField
 		if toOmitted {
 			field.Omitted = true
 		}
 
-		firstApplyFields = append(firstApplyFields, field)
+		firstApplyFields = append(firstApplyFields, &field)
 		if !shouldDelete {
-			secondApplyFields = append(secondApplyFields, field)
+			secondApplyFields = append(secondApplyFields, &field)
 		} else {
 			shouldSecondApply = true
 		}
@@ -340,8 +704,192 @@ func checkFieldsToOmit(oldFields, newFields []interface{}) (firstApplyFields, se
 	return
 }
 
-func newFields(newFields []interface{}) ([]*contentful.Field, diag.Diagnostics) {
-	result := make([]*contentful.Field, len(newFields))
+func findField(fields []*ContentTypeField, id string) (*ContentTypeField, bool) {
+	for _, field := range fields {
+		if field.ID == id {
+			return field, true
+		}
+	}
+	return nil, false
+}
+
+// destructiveFieldChanges returns the IDs of the fields whose content is deleted by changing oldFields to newFields.
+// Contentful does not change the type of a field, so such a field is deleted and created again.
+func destructiveFieldChanges(oldFields, newFields []*ContentTypeField) []string {
+	var ids []string
+	for _, oldField := range oldFields {
+		newField, ok := findField(newFields, oldField.ID)
+		if ok && oldField.Type != newField.Type {
+			ids = append(ids, apiNameOf(oldField))
+		}
+	}
+	return ids
+}
+
+// checkDestructiveFieldChanges fails the plan when it deletes the content of fields, unless allow_destructive_changes is set.
+func checkDestructiveFieldChanges(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChanges("field", "definition_json") || d.Get("allow_destructive_changes").(bool) {
+		return nil
+	}
+	if !d.NewValueKnown("field") || !d.NewValueKnown("definition_json") {
+		return nil
+	}
+
+	oldRawFields, newRawFields := d.GetChange("field")
+	oldDefinition, newDefinition := d.GetChange("definition_json")
+	oldFields, diags := expandContentTypeFields(oldRawFields.([]interface{}), oldDefinition.(string), nil)
+	if diags.HasError() {
+		return nil
+	}
+	oldFields = withInternalIDs(oldFields, oldRawFields.([]interface{}))
+	newFields, diags := expandContentTypeFields(newRawFields.([]interface{}), newDefinition.(string), oldFields)
+	if diags.HasError() {
+		// Invalid fields are reported when they are applied.
+		return nil
+	}
+
+	if ids := destructiveFieldChanges(oldFields, newFields); len(ids) > 0 {
+		return fmt.Errorf("changing the type of fields %s deletes their content in all entries. Set allow_destructive_changes to apply it", strings.Join(ids, ", "))
+	}
+	return nil
+}
+
+// withInternalIDs sets the IDs of the fields built from field blocks of the state to their internal_id.
+func withInternalIDs(fields []*ContentTypeField, rawFields []interface{}) []*ContentTypeField {
+	for i, field := range rawFields {
+		if i >= len(fields) {
+			break
+		}
+		if id, _ := field.(map[string]interface{})["internal_id"].(string); id != "" {
+			fields[i].ID = id
+		}
+	}
+	return fields
+}
+
+// expandContentTypeFields builds the fields from definition_json, or from the field blocks when it is empty.
+// The field blocks are matched with current to send them with the IDs Contentful knows them by.
+func expandContentTypeFields(rawFields []interface{}, definition string, current []*ContentTypeField) ([]*ContentTypeField, diag.Diagnostics) {
+	if definition != "" {
+		fields, err := contentTypeDefinitionFields(definition)
+		if err != nil {
+			return nil, diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "definition_json is invalid.",
+					Detail:        err.Error(),
+					AttributePath: cty.GetAttrPath("definition_json"),
+				},
+			}
+		}
+		return fields, nil
+	}
+
+	fields, diags := newFields(rawFields)
+	if diags.HasError() {
+		return nil, diags
+	}
+	return resolveFieldIDs(current, fields, fieldRenames(rawFields)), nil
+}
+
+// contentTypeDefinition is the part of the JSON of a content type which definition_json defines.
+type contentTypeDefinition struct {
+	Fields []*ContentTypeField `json:"fields"`
+}
+
+func contentTypeDefinitionFields(definition string) ([]*ContentTypeField, error) {
+	var def contentTypeDefinition
+	if err := json.Unmarshal([]byte(definition), &def); err != nil {
+		return nil, err
+	}
+	for i, field := range def.Fields {
+		if field.ID == "" || field.Name == "" || field.Type == "" {
+			return nil, fmt.Errorf("fields[%d] must have id, name and type", i)
+		}
+	}
+	return def.Fields, nil
+}
+
+// newContentTypeDefinition encodes the fields in the normalized JSON which definition_json is compared by.
+// Properties with their default values and apiName equal to the ID are left out.
+func newContentTypeDefinition(fields []*ContentTypeField) (string, error) {
+	normalized := make([]*ContentTypeField, 0, len(fields))
+	for _, field := range fields {
+		f := *field
+		if f.APIName == f.ID {
+			f.APIName = ""
+		}
+		normalized = append(normalized, &f)
+	}
+
+	b, err := json.Marshal(contentTypeDefinition{Fields: normalized})
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func normalizeContentTypeDefinition(definition string) (string, error) {
+	fields, err := contentTypeDefinitionFields(definition)
+	if err != nil {
+		return "", err
+	}
+	return newContentTypeDefinition(fields)
+}
+
+func validateContentTypeDefinition(v interface{}, k string) (warnings []string, errs []error) {
+	if _, err := normalizeContentTypeDefinition(v.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%s is not the JSON of a content type: %w", k, err))
+	}
+	return
+}
+
+func suppressEquivalentContentTypeDefinition(k, old, new string, d *schema.ResourceData) bool {
+	oldNormalized, err := normalizeContentTypeDefinition(old)
+	if err != nil {
+		return false
+	}
+	newNormalized, err := normalizeContentTypeDefinition(new)
+	if err != nil {
+		return false
+	}
+	return oldNormalized == newNormalized
+}
+
+// fieldRenames maps the ID of each renamed field to its rename_from.
+func fieldRenames(fields []interface{}) map[string]string {
+	renames := make(map[string]string)
+	for _, field := range fields {
+		fieldMap := field.(map[string]interface{})
+		if from, _ := fieldMap["rename_from"].(string); from != "" {
+			renames[fieldMap["id"].(string)] = from
+		}
+	}
+	return renames
+}
+
+// resolveFieldIDs replaces the configured IDs of fields with the IDs Contentful knows them by.
+// A field is matched with a current field by its apiName, or by rename_from when it is renamed.
+func resolveFieldIDs(current, fields []*ContentTypeField, renames map[string]string) []*ContentTypeField {
+	ids := make(map[string]string, len(current))
+	for _, field := range current {
+		ids[apiNameOf(field)] = field.ID
+	}
+
+	for _, field := range fields {
+		if id, ok := ids[field.APIName]; ok {
+			field.ID = id
+		} else if from := renames[field.APIName]; from != "" {
+			if id, ok := ids[from]; ok {
+				field.ID = id
+			}
+		}
+	}
+	return fields
+}
+
+func newFields(newFields []interface{}) ([]*ContentTypeField, diag.Diagnostics) {
+	result := make([]*ContentTypeField, len(newFields))
 	diags := make(diag.Diagnostics, 0)
 	for i := 0; i < len(newFields); i++ {
 		newFieldMap := newFields[i].(map[string]interface{})
@@ -354,15 +902,18 @@ func newFields(newFields []interface{}) ([]*contentful.Field, diag.Diagnostics)
 	return result, diags
 }
 
-func newField(newField map[string]interface{}, i int) (*contentful.Field, diag.Diagnostics) {
-	contentfulField := &contentful.Field{
-		ID:        newField["id"].(string),
-		Name:      newField["name"].(string),
-		Type:      newField["type"].(string),
-		Localized: newField["localized"].(bool),
-		Required:  newField["required"].(bool),
-		Disabled:  newField["disabled"].(bool),
-		Omitted:   newField["omitted"].(bool),
+func newField(newField map[string]interface{}, i int) (*ContentTypeField, diag.Diagnostics) {
+	contentfulField := &ContentTypeField{
+		Field: contentful.Field{
+			ID:        newField["id"].(string),
+			Name:      newField["name"].(string),
+			Type:      newField["type"].(string),
+			Localized: newField["localized"].(bool),
+			Required:  newField["required"].(bool),
+			Disabled:  newField["disabled"].(bool),
+			Omitted:   newField["omitted"].(bool),
+		},
+		APIName: newField["id"].(string),
 	}
 
 	if linkType, ok := newField["link_type"].(string); ok {
@@ -392,9 +943,103 @@ func newField(newField map[string]interface{}, i int) (*contentful.Field, diag.D
 	if items := processItems(newField["items"].([]interface{})); items != nil {
 		contentfulField.Items = items
 	}
+
+	if defaultValue, ok := newField["default_value"].(map[string]interface{}); ok && len(defaultValue) > 0 {
+		value, err := expandDefaultValue(contentfulField.Type, defaultValue)
+		if err != nil {
+			return nil, diag.Diagnostics{
+				{
+					Severity: diag.Error,
+					Summary:  "default value is invalid.",
+					Detail:   err.Error(),
+					AttributePath: cty.Path{
+						cty.GetAttrStep{Name: "field"},
+						cty.IndexStep{Key: cty.NumberIntVal(int64(i))},
+						cty.GetAttrStep{Name: "default_value"},
+					},
+				},
+			}
+		}
+		contentfulField.DefaultValue = value
+	}
+
+	if allowedResources, ok := newField["allowed_resources"].([]interface{}); ok {
+		contentfulField.AllowedResources = expandAllowedResources(allowedResources)
+	}
 	return contentfulField, nil
 }
 
+// expandDefaultValue converts the default value of each locale to the type of the field.
+func expandDefaultValue(fieldType string, defaultValue map[string]interface{}) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(defaultValue))
+	for locale, v := range defaultValue {
+		s := v.(string)
+		var value interface{}
+		var err error
+		switch fieldType {
+		case contentful.FieldTypeBoolean:
+			value, err = strconv.ParseBool(s)
+		case contentful.FieldTypeInteger:
+			value, err = strconv.ParseInt(s, 10, 64)
+		case "Number":
+			value, err = strconv.ParseFloat(s, 64)
+		default:
+			value = s
+		}
+		if err != nil {
+			return nil, fmt.Errorf("default value %q of locale %s is not a valid %s value", s, locale, fieldType)
+		}
+		result[locale] = value
+	}
+	return result, nil
+}
+
+func flattenDefaultValue(defaultValue map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(defaultValue))
+	for locale, v := range defaultValue {
+		switch value := v.(type) {
+		case string:
+			result[locale] = value
+		case bool:
+			result[locale] = strconv.FormatBool(value)
+		case float64:
+			result[locale] = strconv.FormatFloat(value, 'f', -1, 64)
+		default:
+			result[locale] = fmt.Sprint(value)
+		}
+	}
+	return result
+}
+
+func expandAllowedResources(allowedResources []interface{}) []*AllowedResource {
+	var result []*AllowedResource
+	for _, v := range allowedResources {
+		resource := v.(map[string]interface{})
+		result = append(result, &AllowedResource{
+			Type:         resource["type"].(string),
+			Source:       resource["source"].(string),
+			ContentTypes: toStrings(resource["content_types"].([]interface{})),
+		})
+	}
+	return result
+}
+
+func flattenAllowedResources(allowedResources []*AllowedResource) []interface{} {
+	result := make([]interface{}, 0, len(allowedResources))
+	for _, resource := range allowedResources {
+		contentTypes := make([]interface{}, 0, len(resource.ContentTypes))
+		for _, contentType := range resource.ContentTypes {
+			contentTypes = append(contentTypes, contentType)
+		}
+		result = append(result, map[string]interface{}{
+			"type":          resource.Type,
+			"source":        resource.Source,
+			"content_types": contentTypes,
+		})
+	}
+	return result
+}
+
 func processItems(fieldItems []interface{}) *contentful.FieldTypeArrayItem {
 	var items *contentful.FieldTypeArrayItem
 
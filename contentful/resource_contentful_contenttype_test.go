@@ -1,12 +1,16 @@
 package contentful
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	contentful "github.com/kitagry/contentful-go"
 )
 
@@ -15,7 +19,7 @@ func TestNewField(t *testing.T) {
 		newField map[string]interface{}
 		i        int
 
-		expectField *contentful.Field
+		expectField *ContentTypeField
 		expectDiags diag.Diagnostics
 	}{
 		"correct field": {
@@ -29,14 +33,75 @@ func TestNewField(t *testing.T) {
 				"omitted":   false,
 				"items":     []interface{}(nil),
 			},
-			expectField: &contentful.Field{
-				ID:        "id",
-				Name:      "name",
-				Type:      "type",
-				Localized: true,
-				Required:  true,
-				Disabled:  false,
-				Omitted:   false,
+			expectField: &ContentTypeField{
+				Field: contentful.Field{
+					ID:        "id",
+					Name:      "name",
+					Type:      "type",
+					Localized: true,
+					Required:  true,
+					Disabled:  false,
+					Omitted:   false,
+				},
+				APIName: "id",
+			},
+		},
+		"default value and allowed resources": {
+			newField: map[string]interface{}{
+				"id":            "count",
+				"name":          "Count",
+				"type":          "Integer",
+				"localized":     false,
+				"required":      false,
+				"disabled":      false,
+				"omitted":       false,
+				"items":         []interface{}(nil),
+				"default_value": map[string]interface{}{"en-US": "10"},
+				"allowed_resources": []interface{}{
+					map[string]interface{}{
+						"type":          "Contentful:Entry",
+						"source":        "crn:contentful:::content:spaces/other",
+						"content_types": []interface{}{"post"},
+					},
+				},
+			},
+			expectField: &ContentTypeField{
+				Field: contentful.Field{
+					ID:   "count",
+					Name: "Count",
+					Type: "Integer",
+				},
+				APIName:      "count",
+				DefaultValue: map[string]interface{}{"en-US": int64(10)},
+				AllowedResources: []*AllowedResource{
+					{Type: "Contentful:Entry", Source: "crn:contentful:::content:spaces/other", ContentTypes: []string{"post"}},
+				},
+			},
+		},
+		"invalid default value": {
+			newField: map[string]interface{}{
+				"id":            "count",
+				"name":          "Count",
+				"type":          "Integer",
+				"localized":     false,
+				"required":      false,
+				"disabled":      false,
+				"omitted":       false,
+				"items":         []interface{}(nil),
+				"default_value": map[string]interface{}{"en-US": "ten"},
+			},
+			i: 1,
+			expectDiags: diag.Diagnostics{
+				{
+					Severity: diag.Error,
+					Summary:  "default value is invalid.",
+					Detail:   `default value "ten" of locale en-US is not a valid Integer value`,
+					AttributePath: cty.Path{
+						cty.GetAttrStep{Name: "field"},
+						cty.IndexStep{Key: cty.NumberIntVal(1)},
+						cty.GetAttrStep{Name: "default_value"},
+					},
+				},
 			},
 		},
 		"invalid json": {
@@ -79,3 +144,302 @@ func TestNewField(t *testing.T) {
 		})
 	}
 }
+
+func TestSetFieldProperties(t *testing.T) {
+	fields := []interface{}{
+		map[string]interface{}{"id": "headline", "name": "Headline", "type": "Symbol"},
+		map[string]interface{}{"id": "published", "name": "Published", "type": "Boolean"},
+	}
+	ct := &ContentType{
+		Fields: []*ContentTypeField{
+			{Field: contentful.Field{ID: "title", Type: "Symbol"}, APIName: "headline"},
+			{Field: contentful.Field{ID: "published", Type: "Boolean"}, DefaultValue: map[string]interface{}{"en-US": false}},
+		},
+		Metadata: &ContentTypeMetadata{
+			Annotations: &ContentTypeAnnotations{
+				ContentTypeField: map[string][]contentful.Entity{"title": newAnnotationLinks([]string{"Contentful:AggregateComponent"})},
+			},
+		},
+	}
+
+	expect := []interface{}{
+		map[string]interface{}{
+			"id": "headline", "name": "Headline", "type": "Symbol",
+			"internal_id": "title", "default_value": map[string]interface{}{}, "allowed_resources": []interface{}{},
+			"annotations": []interface{}{"Contentful:AggregateComponent"},
+		},
+		map[string]interface{}{
+			"id": "published", "name": "Published", "type": "Boolean",
+			"internal_id": "published", "default_value": map[string]interface{}{"en-US": "false"}, "allowed_resources": []interface{}{},
+			"annotations": []interface{}{},
+		},
+	}
+	if diff := cmp.Diff(expect, setFieldProperties(fields, ct)); diff != "" {
+		t.Errorf("setFieldProperties result diff (-expect, +got)\n%s", diff)
+	}
+}
+
+func TestResourceContentTypeDelete(t *testing.T) {
+	tests := map[string]struct {
+		deleteEntries bool
+
+		expectErr     bool
+		expectDeleted bool
+	}{
+		"content type with entries should not be deactivated": {
+			deleteEntries: false,
+			expectErr:     true,
+		},
+		"delete_entries_on_destroy should delete the entries first": {
+			deleteEntries: true,
+			expectDeleted: true,
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			ctx := context.Background()
+			client, env := newFakeCMAEnvironment(t)
+
+			contentTypes := &contentTypesService{c: newCMAClient(client)}
+			ct := &ContentType{
+				Sys:          &contentful.Sys{ID: "post"},
+				Name:         "Post",
+				DisplayField: "title",
+				Fields:       []*ContentTypeField{{Field: contentful.Field{ID: "title", Name: "Title", Type: "Symbol"}}},
+			}
+			if err := upsertAndActivate(ctx, contentTypes, env, ct); err != nil {
+				t.Fatal(err)
+			}
+			// More entries than a page, some of them published.
+			for i := 0; i < contentTypeEntriesPageSize+5; i++ {
+				entry := &contentful.Entry{
+					Sys:    &contentful.Sys{ID: fmt.Sprintf("entry%d", i)},
+					Fields: map[string]interface{}{"title": map[string]interface{}{"en-US": "title"}},
+				}
+				if err := client.Entries.Upsert(ctx, env, "post", entry); err != nil {
+					t.Fatal(err)
+				}
+				if i%2 == 0 {
+					if err := client.Entries.Publish(ctx, env, entry); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceContentfulContentType().Schema, map[string]interface{}{
+				"space_id":                  "space",
+				"env_id":                    "master",
+				"delete_entries_on_destroy": tt.deleteEntries,
+			})
+			d.SetId("post")
+			diags := resourceContentTypeDelete(ctx, d, env, contentTypes, newContentTypeEntriesClient(client))
+			if diags.HasError() != tt.expectErr {
+				t.Fatalf("resourceContentTypeDelete() diags = %v, expectErr %v", diags, tt.expectErr)
+			}
+
+			got, err := contentTypes.Get(ctx, env, "post")
+			if tt.expectDeleted {
+				if err == nil {
+					t.Error("content type should be deleted")
+				}
+				return
+			}
+			if err != nil || got.Sys.PublishedVersion == 0 {
+				t.Errorf("content type should stay active: %v", err)
+			}
+		})
+	}
+}
+
+func TestDestructiveFieldChanges(t *testing.T) {
+	field := func(id, apiName, typ string) *ContentTypeField {
+		return &ContentTypeField{Field: contentful.Field{ID: id, Type: typ}, APIName: apiName}
+	}
+
+	tests := map[string]struct {
+		oldFields []*ContentTypeField
+		newFields []*ContentTypeField
+
+		expect []string
+	}{
+		"changed type should delete the content": {
+			oldFields: []*ContentTypeField{field("title", "title", "Symbol"), field("body", "body", "Text")},
+			newFields: []*ContentTypeField{field("title", "title", "Text"), field("body", "body", "Text")},
+			expect:    []string{"title"},
+		},
+		"renamed field should keep the content": {
+			oldFields: []*ContentTypeField{field("title", "title", "Symbol")},
+			newFields: []*ContentTypeField{field("title", "headline", "Symbol")},
+		},
+		"renamed field with changed type should delete the content": {
+			oldFields: []*ContentTypeField{field("title", "title", "Symbol")},
+			newFields: []*ContentTypeField{field("title", "headline", "Text")},
+			expect:    []string{"title"},
+		},
+		"removed field is not a type change": {
+			oldFields: []*ContentTypeField{field("title", "title", "Symbol")},
+			newFields: []*ContentTypeField{},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			got := destructiveFieldChanges(tt.oldFields, tt.newFields)
+			if diff := cmp.Diff(tt.expect, got); diff != "" {
+				t.Errorf("destructiveFieldChanges result diff (-expect, +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResourceContentTypeUpdate_renameField(t *testing.T) {
+	ctx := context.Background()
+	client, env := newFakeCMAEnvironment(t)
+	contentTypes := &contentTypesService{c: newCMAClient(client)}
+	entries := newContentTypeEntriesClient(client)
+
+	r := resourceContentfulContentType()
+	config := func(id, renameFrom string) map[string]interface{} {
+		return map[string]interface{}{
+			"space_id":        "space",
+			"env_id":          "master",
+			"content_type_id": "post",
+			"name":            "Post",
+			"display_field":   id,
+			"annotations":     []interface{}{"Contentful:AggregateRoot"},
+			"field": []interface{}{
+				map[string]interface{}{"id": id, "name": "Title", "type": "Symbol", "rename_from": renameFrom, "annotations": []interface{}{"Contentful:AggregateComponent"}},
+			},
+		}
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config("title", ""))
+	if diags := resourceContentTypeCreate(ctx, d, env, contentTypes, entries); diags.HasError() {
+		t.Fatalf("resourceContentTypeCreate() diags = %v", diags)
+	}
+
+	// The field keeps its first ID through renames.
+	renameFrom := "title"
+	for _, id := range []string{"headline", "heading"} {
+		state := d.State()
+		diff, err := r.SimpleDiff(ctx, state, terraform.NewResourceConfigRaw(config(id, renameFrom)), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, err = schema.InternalMap(r.Schema).Data(state, diff)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diags := resourceContentTypeUpdate(ctx, d, env, contentTypes, entries); diags.HasError() {
+			t.Fatalf("resourceContentTypeUpdate() diags = %v", diags)
+		}
+
+		ct, err := contentTypes.Get(ctx, env, "post")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect := []*ContentTypeField{{Field: contentful.Field{ID: "title", Name: "Title", Type: "Symbol"}, APIName: id}}
+		if diff := cmp.Diff(expect, ct.Fields); diff != "" {
+			t.Errorf("fields after renaming to %s diff (-expect, +got)\n%s", id, diff)
+		}
+		expectMetadata := &ContentTypeMetadata{
+			Annotations: &ContentTypeAnnotations{
+				ContentType:      newAnnotationLinks([]string{"Contentful:AggregateRoot"}),
+				ContentTypeField: map[string][]contentful.Entity{"title": newAnnotationLinks([]string{"Contentful:AggregateComponent"})},
+			},
+		}
+		if diff := cmp.Diff(expectMetadata, ct.Metadata); diff != "" {
+			t.Errorf("metadata after renaming to %s diff (-expect, +got)\n%s", id, diff)
+		}
+		renameFrom = id
+	}
+}
+
+func TestSuppressEquivalentContentTypeDefinition(t *testing.T) {
+	tests := map[string]struct {
+		old string
+		new string
+
+		expect bool
+	}{
+		"export should be equivalent to its fields": {
+			old:    `{"fields":[{"id":"title","name":"Title","type":"Symbol","required":true}]}`,
+			new:    `{"sys":{"id":"post","version":3},"name":"Post","fields":[{"id":"title","apiName":"title","name":"Title","type":"Symbol","required":true,"localized":false,"validations":[]}]}`,
+			expect: true,
+		},
+		"changed field should not be equivalent": {
+			old:    `{"fields":[{"id":"title","name":"Title","type":"Symbol"}]}`,
+			new:    `{"fields":[{"id":"title","name":"Title","type":"Text"}]}`,
+			expect: false,
+		},
+		"renamed field should not be equivalent": {
+			old:    `{"fields":[{"id":"title","name":"Title","type":"Symbol"}]}`,
+			new:    `{"fields":[{"id":"title","apiName":"headline","name":"Title","type":"Symbol"}]}`,
+			expect: false,
+		},
+		"invalid json should not be equivalent": {
+			old:    `{"fields":[]}`,
+			new:    `{"fields":`,
+			expect: false,
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			got := suppressEquivalentContentTypeDefinition("definition_json", tt.old, tt.new, nil)
+			if got != tt.expect {
+				t.Errorf("suppressEquivalentContentTypeDefinition() = %v, expect %v", got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestResourceContentTypeUpdate_definitionJSON(t *testing.T) {
+	ctx := context.Background()
+	client, env := newFakeCMAEnvironment(t)
+	contentTypes := &contentTypesService{c: newCMAClient(client)}
+	entries := newContentTypeEntriesClient(client)
+
+	r := resourceContentfulContentType()
+	config := func(definition string) map[string]interface{} {
+		return map[string]interface{}{
+			"space_id":                  "space",
+			"env_id":                    "master",
+			"content_type_id":           "post",
+			"name":                      "Post",
+			"display_field":             "title",
+			"allow_destructive_changes": true,
+			"definition_json":           definition,
+		}
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config(`{"fields":[
+		{"id":"title","name":"Title","type":"Symbol","required":true},
+		{"id":"body","name":"Body","type":"Text"}
+	]}`))
+	if diags := resourceContentTypeCreate(ctx, d, env, contentTypes, entries); diags.HasError() {
+		t.Fatalf("resourceContentTypeCreate() diags = %v", diags)
+	}
+
+	// The type of the display field changes and body is removed.
+	state := d.State()
+	diff, err := r.SimpleDiff(ctx, state, terraform.NewResourceConfigRaw(config(`{"fields":[{"id":"title","name":"Title","type":"Text","required":true}]}`)), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diags := resourceContentTypeUpdate(ctx, d, env, contentTypes, entries)
+	if diags.HasError() || len(diags) != 1 {
+		t.Fatalf("resourceContentTypeUpdate() should warn about the deleted content, diags = %v", diags)
+	}
+
+	if diags := resourceContentTypeRead(ctx, d, env, contentTypes, entries); diags.HasError() {
+		t.Fatalf("resourceContentTypeRead() diags = %v", diags)
+	}
+	expect := `{"fields":[{"id":"title","name":"Title","type":"Text","required":true}]}`
+	if got := d.Get("definition_json").(string); got != expect {
+		t.Errorf("definition_json = %s, expect %s", got, expect)
+	}
+}
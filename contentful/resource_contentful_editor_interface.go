@@ -0,0 +1,402 @@
+package contentful
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+func resourceContentfulEditorInterface() *schema.Resource {
+	settingsJSON := func() *schema.Schema {
+		return &schema.Schema{
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validateEditorInterfaceSettings),
+			DiffSuppressFunc: suppressEquivalentEditorInterfaceSettings,
+			Description:      "The settings of the widget as a JSON object, such as `{\"helpText\":\"...\"}`. It is compared by its meaning rather than its text",
+		}
+	}
+
+	return &schema.Resource{
+		Description:   "Manages the editor interface of a content type, which selects the widgets editing the fields of its entries. Contentful creates the editor interface with the content type and does not delete it, so destroying the resource leaves the editor interface as it is",
+		CreateContext: wrapEditorInterface(resourceEditorInterfaceCreate),
+		ReadContext:   wrapEditorInterface(resourceEditorInterfaceRead),
+		UpdateContext: wrapEditorInterface(resourceEditorInterfaceUpdate),
+		DeleteContext: resourceEditorInterfaceDelete,
+		CustomizeDiff: setProviderDefaults,
+		Importer:      importEnvironmentEntity("content_type_id"),
+
+		Schema: map[string]*schema.Schema{
+			"space_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the space. Defaults to `space_id` of the provider",
+			},
+			"env_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the environment. Defaults to `environment_id` of the provider",
+			},
+			"content_type_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the content type",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The current version of the editor interface, which increases on every change",
+			},
+			"control": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"field_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the field",
+						},
+						"widget_namespace": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "builtin",
+							Description: "The namespace of the widget: `builtin`, `extension` or `app`",
+						},
+						"widget_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the widget, such as `singleLine` or `markdown`",
+						},
+						"settings_json": settingsJSON(),
+					},
+				},
+				Description: "The widgets of the fields. Only the controls of these fields are managed, and a field whose control is removed gets the default widget of its type. All controls are read when the resource is imported",
+			},
+			"sidebar": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"widget_namespace": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The namespace of the widget: `sidebar-builtin`, `extension` or `app`",
+						},
+						"widget_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the widget, such as `publication-widget`",
+						},
+						"settings_json": settingsJSON(),
+						"disabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the widget is hidden",
+						},
+					},
+				},
+				Description: "The widgets of the sidebar in their order. The default sidebar is shown when it is omitted",
+			},
+		},
+	}
+}
+
+func wrapEditorInterface(f func(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEnvironmentEntityClient) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+		meta := m.(*providerMeta)
+		client := meta.client
+		env, err := meta.environments.Get(ctx, client.Environments, d.Get("space_id").(string), d.Get("env_id").(string))
+		if err != nil {
+			diags = append(diags, contentfulErrorToDiagnostic(err)...)
+			return
+		}
+		return f(ctx, d, env, &environmentEntitiesService{c: newCMAClient(client)})
+	}
+}
+
+func editorInterfacePath(contentTypeID string) string {
+	return "content_types/" + contentTypeID + "/editor_interface"
+}
+
+func resourceEditorInterfaceCreate(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEnvironmentEntityClient) (diags diag.Diagnostics) {
+	// The editor interface already exists, since Contentful creates it with the content type.
+	d.SetId(d.Get("content_type_id").(string))
+	return putEditorInterface(ctx, d, env, client, nil)
+}
+
+func resourceEditorInterfaceUpdate(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEnvironmentEntityClient) (diags diag.Diagnostics) {
+	oldControls, _ := d.GetChange("control")
+	return putEditorInterface(ctx, d, env, client, oldControls.([]interface{}))
+}
+
+// putEditorInterface writes the configured controls and sidebar over the current editor interface. The controls of
+// fields which are not configured are kept, except those of oldControls, which are reset to the default widget.
+func putEditorInterface(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEnvironmentEntityClient, oldControls []interface{}) (diags diag.Diagnostics) {
+	path := editorInterfacePath(d.Id())
+	doc, err := client.Get(ctx, env, path)
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	controls, err := expandEditorInterfaceControls(d.Get("control").([]interface{}))
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+	reset := map[string]bool{}
+	for _, raw := range oldControls {
+		reset[raw.(map[string]interface{})["field_id"].(string)] = true
+	}
+	doc["controls"] = mergeEditorInterfaceControls(doc["controls"], controls, reset)
+
+	sidebar, err := expandEditorInterfaceSidebar(d.Get("sidebar").([]interface{}))
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+	if len(sidebar) > 0 {
+		doc["sidebar"] = sidebar
+	} else {
+		delete(doc, "sidebar")
+	}
+
+	doc, err = client.Put(ctx, env, path, doc.Version(), doc, "")
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	if err := setEditorInterfaceProperties(d, doc, false); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+	}
+	return
+}
+
+func resourceEditorInterfaceRead(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEnvironmentEntityClient) (diags diag.Diagnostics) {
+	doc, err := client.Get(ctx, env, editorInterfacePath(d.Id()))
+	if _, ok := err.(contentful.NotFoundError); ok {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	// Only an imported editor interface has no version yet.
+	if err := setEditorInterfaceProperties(d, doc, d.Get("version").(int) == 0); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+	}
+	return
+}
+
+// resourceEditorInterfaceDelete only forgets the editor interface, which is deleted with its content type.
+func resourceEditorInterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+	return
+}
+
+// setEditorInterfaceProperties sets the properties of doc. Unless allControls is true, only the controls of the
+// fields in control are set, in their order.
+func setEditorInterfaceProperties(d *schema.ResourceData, doc EntityDocument, allControls bool) error {
+	if err := d.Set("content_type_id", d.Id()); err != nil {
+		return err
+	}
+	if err := d.Set("version", doc.Version()); err != nil {
+		return err
+	}
+
+	controls, err := flattenEditorInterfaceWidgets(doc["controls"], "fieldId")
+	if err != nil {
+		return err
+	}
+	if allControls {
+		// Controls without a widget have the default widget of their field, like controls which are not managed.
+		withWidget := make([]interface{}, 0, len(controls))
+		for _, control := range controls {
+			if control.(map[string]interface{})["widget_id"] != "" {
+				withWidget = append(withWidget, control)
+			}
+		}
+		controls = withWidget
+	} else {
+		byField := make(map[string]interface{}, len(controls))
+		for _, control := range controls {
+			byField[control.(map[string]interface{})["field_id"].(string)] = control
+		}
+		managed := make([]interface{}, 0, len(controls))
+		for _, raw := range d.Get("control").([]interface{}) {
+			if control, ok := byField[raw.(map[string]interface{})["field_id"].(string)]; ok {
+				managed = append(managed, control)
+			}
+		}
+		controls = managed
+	}
+	if err := d.Set("control", controls); err != nil {
+		return err
+	}
+
+	sidebar, err := flattenEditorInterfaceWidgets(doc["sidebar"], "")
+	if err != nil {
+		return err
+	}
+	return d.Set("sidebar", sidebar)
+}
+
+// flattenEditorInterfaceWidgets converts the controls or the sidebar widgets of an editor interface to blocks.
+// idKey is the property of the field ID of a control, and empty for sidebar widgets.
+func flattenEditorInterfaceWidgets(v interface{}, idKey string) ([]interface{}, error) {
+	widgets, _ := v.([]interface{})
+	blocks := make([]interface{}, 0, len(widgets))
+	for _, w := range widgets {
+		widget, ok := w.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		namespace, _ := widget["widgetNamespace"].(string)
+		widgetID, _ := widget["widgetId"].(string)
+		block := map[string]interface{}{
+			"widget_namespace": namespace,
+			"widget_id":        widgetID,
+			"settings_json":    "",
+		}
+		if settings, ok := widget["settings"].(map[string]interface{}); ok && len(settings) > 0 {
+			b, err := json.Marshal(settings)
+			if err != nil {
+				return nil, err
+			}
+			block["settings_json"] = string(b)
+		}
+		if idKey != "" {
+			fieldID, _ := widget[idKey].(string)
+			block["field_id"] = fieldID
+		} else {
+			disabled, _ := widget["disabled"].(bool)
+			block["disabled"] = disabled
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+func expandEditorInterfaceControls(rawControls []interface{}) ([]map[string]interface{}, error) {
+	controls := make([]map[string]interface{}, 0, len(rawControls))
+	for _, raw := range rawControls {
+		block := raw.(map[string]interface{})
+		control, err := expandEditorInterfaceWidget(block)
+		if err != nil {
+			return nil, fmt.Errorf("control of %s: %w", block["field_id"], err)
+		}
+		control["fieldId"] = block["field_id"]
+		controls = append(controls, control)
+	}
+	return controls, nil
+}
+
+func expandEditorInterfaceSidebar(rawSidebar []interface{}) ([]map[string]interface{}, error) {
+	sidebar := make([]map[string]interface{}, 0, len(rawSidebar))
+	for _, raw := range rawSidebar {
+		block := raw.(map[string]interface{})
+		widget, err := expandEditorInterfaceWidget(block)
+		if err != nil {
+			return nil, fmt.Errorf("sidebar widget %s: %w", block["widget_id"], err)
+		}
+		if block["disabled"].(bool) {
+			widget["disabled"] = true
+		}
+		sidebar = append(sidebar, widget)
+	}
+	return sidebar, nil
+}
+
+func expandEditorInterfaceWidget(block map[string]interface{}) (map[string]interface{}, error) {
+	widget := map[string]interface{}{
+		"widgetNamespace": block["widget_namespace"],
+		"widgetId":        block["widget_id"],
+	}
+	if s := block["settings_json"].(string); s != "" {
+		var settings map[string]interface{}
+		if err := json.Unmarshal([]byte(s), &settings); err != nil {
+			return nil, fmt.Errorf("invalid settings_json: %w", err)
+		}
+		widget["settings"] = settings
+	}
+	return widget, nil
+}
+
+// mergeEditorInterfaceControls replaces the current controls of the configured fields. The controls of the fields
+// in reset get the default widget, and other controls are kept as they are.
+func mergeEditorInterfaceControls(current interface{}, controls []map[string]interface{}, reset map[string]bool) []interface{} {
+	configured := make(map[string]map[string]interface{}, len(controls))
+	for _, control := range controls {
+		configured[control["fieldId"].(string)] = control
+	}
+
+	currentControls, _ := current.([]interface{})
+	merged := make([]interface{}, 0, len(currentControls)+len(controls))
+	for _, c := range currentControls {
+		control, _ := c.(map[string]interface{})
+		fieldID, _ := control["fieldId"].(string)
+		switch {
+		case configured[fieldID] != nil:
+			merged = append(merged, configured[fieldID])
+			delete(configured, fieldID)
+		case reset[fieldID]:
+			merged = append(merged, map[string]interface{}{"fieldId": fieldID})
+		default:
+			merged = append(merged, c)
+		}
+	}
+	for _, control := range controls {
+		if configured[control["fieldId"].(string)] != nil {
+			merged = append(merged, control)
+		}
+	}
+	return merged
+}
+
+func normalizeEditorInterfaceSettings(settings string) (string, error) {
+	if settings == "" {
+		return "", nil
+	}
+	var v map[string]interface{}
+	if err := json.Unmarshal([]byte(settings), &v); err != nil {
+		return "", err
+	}
+	if len(v) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+func validateEditorInterfaceSettings(v interface{}, k string) (warnings []string, errs []error) {
+	if _, err := normalizeEditorInterfaceSettings(v.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%s is not a JSON object: %w", k, err))
+	}
+	return
+}
+
+func suppressEquivalentEditorInterfaceSettings(k, old, new string, d *schema.ResourceData) bool {
+	oldNormalized, err := normalizeEditorInterfaceSettings(old)
+	if err != nil {
+		return false
+	}
+	newNormalized, err := normalizeEditorInterfaceSettings(new)
+	if err != nil {
+		return false
+	}
+	return oldNormalized == newNormalized
+}
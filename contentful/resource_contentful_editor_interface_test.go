@@ -0,0 +1,137 @@
+package contentful
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+func TestResourceEditorInterface(t *testing.T) {
+	ctx := context.Background()
+	client, env := newFakeCMAEnvironment(t)
+	entities := &environmentEntitiesService{c: newCMAClient(client)}
+
+	ct := &ContentType{
+		Sys:          &contentful.Sys{ID: "post"},
+		Name:         "Post",
+		DisplayField: "title",
+		Fields: []*ContentTypeField{
+			{Field: contentful.Field{ID: "title", Name: "Title", Type: "Symbol"}},
+			{Field: contentful.Field{ID: "body", Name: "Body", Type: "Text"}},
+			{Field: contentful.Field{ID: "slug", Name: "Slug", Type: "Symbol"}},
+		},
+	}
+	if err := upsertAndActivate(ctx, &contentTypesService{c: newCMAClient(client)}, env, ct); err != nil {
+		t.Fatal(err)
+	}
+	// The control of slug is not managed and is kept.
+	doc, err := entities.Get(ctx, env, editorInterfacePath("post"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc["controls"] = []interface{}{
+		map[string]interface{}{"fieldId": "title"},
+		map[string]interface{}{"fieldId": "body"},
+		map[string]interface{}{"fieldId": "slug", "widgetNamespace": "builtin", "widgetId": "slugEditor"},
+	}
+	if _, err := entities.Put(ctx, env, editorInterfacePath("post"), doc.Version(), doc, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	r := resourceContentfulEditorInterface()
+	config := map[string]interface{}{
+		"space_id":        "space",
+		"env_id":          "master",
+		"content_type_id": "post",
+		"control": []interface{}{
+			map[string]interface{}{"field_id": "body", "widget_id": "markdown", "settings_json": `{"helpText": "Markdown"}`},
+			map[string]interface{}{"field_id": "title", "widget_id": "singleLine"},
+		},
+		"sidebar": []interface{}{
+			map[string]interface{}{"widget_namespace": "sidebar-builtin", "widget_id": "publication-widget"},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := resourceEditorInterfaceCreate(ctx, d, env, entities); diags.HasError() {
+		t.Fatalf("resourceEditorInterfaceCreate() diags = %v", diags)
+	}
+
+	got, err := entities.Get(ctx, env, editorInterfacePath("post"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectControls := []interface{}{
+		map[string]interface{}{"fieldId": "title", "widgetNamespace": "builtin", "widgetId": "singleLine"},
+		map[string]interface{}{"fieldId": "body", "widgetNamespace": "builtin", "widgetId": "markdown", "settings": map[string]interface{}{"helpText": "Markdown"}},
+		map[string]interface{}{"fieldId": "slug", "widgetNamespace": "builtin", "widgetId": "slugEditor"},
+	}
+	if diff := cmp.Diff(expectControls, got["controls"]); diff != "" {
+		t.Errorf("controls diff (-expect, +got)\n%s", diff)
+	}
+	expectSidebar := []interface{}{
+		map[string]interface{}{"widgetNamespace": "sidebar-builtin", "widgetId": "publication-widget"},
+	}
+	if diff := cmp.Diff(expectSidebar, got["sidebar"]); diff != "" {
+		t.Errorf("sidebar diff (-expect, +got)\n%s", diff)
+	}
+	// Only the configured controls are in the state, in the configured order.
+	expectState := []interface{}{
+		map[string]interface{}{"field_id": "body", "widget_namespace": "builtin", "widget_id": "markdown", "settings_json": `{"helpText":"Markdown"}`},
+		map[string]interface{}{"field_id": "title", "widget_namespace": "builtin", "widget_id": "singleLine", "settings_json": ""},
+	}
+	if diff := cmp.Diff(expectState, d.Get("control")); diff != "" {
+		t.Errorf("control diff (-expect, +got)\n%s", diff)
+	}
+
+	// The removed control of body gets the default widget, and the omitted sidebar is the default sidebar.
+	config["control"] = []interface{}{
+		map[string]interface{}{"field_id": "title", "widget_id": "singleLine"},
+	}
+	delete(config, "sidebar")
+	state := d.State()
+	diff, err := r.SimpleDiff(ctx, state, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceEditorInterfaceUpdate(ctx, d, env, entities); diags.HasError() {
+		t.Fatalf("resourceEditorInterfaceUpdate() diags = %v", diags)
+	}
+
+	got, err = entities.Get(ctx, env, editorInterfacePath("post"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectControls = []interface{}{
+		map[string]interface{}{"fieldId": "title", "widgetNamespace": "builtin", "widgetId": "singleLine"},
+		map[string]interface{}{"fieldId": "body"},
+		map[string]interface{}{"fieldId": "slug", "widgetNamespace": "builtin", "widgetId": "slugEditor"},
+	}
+	if diff := cmp.Diff(expectControls, got["controls"]); diff != "" {
+		t.Errorf("controls after update diff (-expect, +got)\n%s", diff)
+	}
+	if _, ok := got["sidebar"]; ok {
+		t.Errorf("sidebar = %v, should be removed", got["sidebar"])
+	}
+
+	// An imported editor interface reads every control with a widget.
+	imported := r.Data(nil)
+	imported.SetId("post")
+	if diags := resourceEditorInterfaceRead(ctx, imported, env, entities); diags.HasError() {
+		t.Fatalf("resourceEditorInterfaceRead() diags = %v", diags)
+	}
+	expectState = []interface{}{
+		map[string]interface{}{"field_id": "title", "widget_namespace": "builtin", "widget_id": "singleLine", "settings_json": ""},
+		map[string]interface{}{"field_id": "slug", "widget_namespace": "builtin", "widget_id": "slugEditor", "settings_json": ""},
+	}
+	if diff := cmp.Diff(expectState, imported.Get("control")); diff != "" {
+		t.Errorf("control after import diff (-expect, +got)\n%s", diff)
+	}
+}
@@ -0,0 +1,427 @@
+package contentful
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+func resourceContentfulEntries() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a set of entries of a content type from a single JSON document, such as reference data. Each entry is created, updated or deleted only when it differs from Contentful, and the entries are published or unpublished in bulk actions of up to 200 entries",
+		CreateContext: wrapEntries(resourceEntriesCreate),
+		ReadContext:   wrapEntries(resourceEntriesRead),
+		UpdateContext: wrapEntries(resourceEntriesUpdate),
+		DeleteContext: wrapEntries(resourceEntriesDelete),
+		CustomizeDiff: customdiff.All(setProviderDefaults, planBulkEntriesState),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"space_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the space. Defaults to `space_id` of the provider",
+			},
+			"env_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the environment. Defaults to `environment_id` of the provider",
+			},
+			"content_type_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the content type of the entries",
+			},
+			"entries_json": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateBulkEntries),
+				DiffSuppressFunc: suppressEquivalentBulkEntries,
+				Description:      "The entries as a JSON array of objects with `id` and `fields`, where `fields` maps each field ID to its values by locale like the entries of a Contentful export. It is compared by its meaning rather than its text, so a YAML document can be given by `jsonencode(yamldecode(...))`. Values changed outside of Terraform are refreshed, and entries which are removed from the array are deleted",
+			},
+			"published": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the entries are published. An entry changed after the last publish is published again",
+			},
+			"statuses": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The publication status of each entry by its ID: `draft`, `published`, `changed` or `archived`",
+			},
+		},
+	}
+}
+
+// bulkEntry is an entry of entries_json.
+type bulkEntry struct {
+	ID     string                            `json:"id"`
+	Fields map[string]map[string]interface{} `json:"fields"`
+}
+
+func expandBulkEntries(document string) ([]*bulkEntry, error) {
+	var entries []*bulkEntry
+	if err := json.Unmarshal([]byte(document), &entries); err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(entries))
+	for i, entry := range entries {
+		if entry == nil || entry.ID == "" {
+			return nil, fmt.Errorf("[%d] must have id", i)
+		}
+		if seen[entry.ID] {
+			return nil, fmt.Errorf("[%d]: entry ID %s is used twice", i, entry.ID)
+		}
+		seen[entry.ID] = true
+		if entry.Fields == nil {
+			entry.Fields = map[string]map[string]interface{}{}
+		}
+	}
+	return entries, nil
+}
+
+// newBulkEntriesDocument encodes the entries ordered by ID, which is the normalized JSON entries_json is compared by.
+func newBulkEntriesDocument(entries []*bulkEntry) (string, error) {
+	sorted := make([]*bulkEntry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	b, err := json.Marshal(sorted)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func normalizeBulkEntries(document string) (string, error) {
+	entries, err := expandBulkEntries(document)
+	if err != nil {
+		return "", err
+	}
+	return newBulkEntriesDocument(entries)
+}
+
+func validateBulkEntries(v interface{}, k string) (warnings []string, errs []error) {
+	if _, err := normalizeBulkEntries(v.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%s is not a JSON array of entries: %w", k, err))
+	}
+	return
+}
+
+func suppressEquivalentBulkEntries(k, old, new string, d *schema.ResourceData) bool {
+	oldNormalized, err := normalizeBulkEntries(old)
+	if err != nil {
+		return false
+	}
+	newNormalized, err := normalizeBulkEntries(new)
+	if err != nil {
+		return false
+	}
+	return oldNormalized == newNormalized
+}
+
+// bulkEntryFields converts the fields of an entry read from Contentful into the fields of entries_json.
+func bulkEntryFields(fields map[string]interface{}) map[string]map[string]interface{} {
+	result := make(map[string]map[string]interface{}, len(fields))
+	for id, value := range fields {
+		if values, ok := value.(map[string]interface{}); ok {
+			result[id] = values
+		}
+	}
+	return result
+}
+
+// equalBulkEntryFields compares the fields by their JSON, so that numbers read from Contentful equal the configured ones.
+func equalBulkEntryFields(entry *bulkEntry, current *contentful.Entry) bool {
+	want, err := json.Marshal(entry.Fields)
+	if err != nil {
+		return false
+	}
+	got, err := json.Marshal(bulkEntryFields(current.Fields))
+	if err != nil {
+		return false
+	}
+	return string(want) == string(got)
+}
+
+func wrapEntries(f func(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, m *entriesClients) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+		meta := m.(*providerMeta)
+		client := meta.client
+		env, err := meta.environments.Get(ctx, client.Environments, d.Get("space_id").(string), d.Get("env_id").(string))
+		if err != nil {
+			diags = append(diags, contentfulErrorToDiagnostic(err)...)
+			return
+		}
+		return f(ctx, d, env, &entriesClients{
+			entries:     newContentTypeEntriesClient(client),
+			bulkActions: &bulkActionsService{c: newCMAClient(client)},
+		})
+	}
+}
+
+// entriesClients are the clients contentful_entries uses. bulkActions publishes and unpublishes the entries.
+type entriesClients struct {
+	entries     contentTypeEntriesClient
+	bulkActions ContentfulBulkActionClient
+}
+
+func resourceEntriesCreate(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, m *entriesClients) (diags diag.Diagnostics) {
+	d.SetId(resource.UniqueId())
+
+	diags = applyBulkEntries(ctx, d, env, m, nil)
+	return append(diags, resourceEntriesRead(ctx, d, env, m)...)
+}
+
+func resourceEntriesRead(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, m *entriesClients) (diags diag.Diagnostics) {
+	entries, err := expandBulkEntries(d.Get("entries_json").(string))
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	current, err := listBulkEntries(ctx, m.entries, env, d.Get("content_type_id").(string), bulkEntryIDs(entries))
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	// Entries deleted outside of Terraform are left out, so that they are created again.
+	refreshed := make([]*bulkEntry, 0, len(entries))
+	statuses := make(map[string]interface{}, len(entries))
+	published := true
+	for _, entry := range entries {
+		c, ok := current[entry.ID]
+		if !ok {
+			continue
+		}
+		refreshed = append(refreshed, &bulkEntry{ID: entry.ID, Fields: bulkEntryFields(c.Fields)})
+		statuses[entry.ID] = entityStatus(c.Sys)
+		// An entry with changes after the last publish is still published. planBulkEntriesState publishes it again.
+		if !hasPublishedVersion(c.Sys) {
+			published = false
+		}
+	}
+
+	document, err := newBulkEntriesDocument(refreshed)
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+	if err := d.Set("entries_json", document); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+	if err := d.Set("published", published); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+	if err := d.Set("statuses", statuses); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+	}
+	return
+}
+
+// planBulkEntriesState plans publishing or unpublishing the entries whose status does not match published. published
+// alone does not show them when some entries are changed after the last publish, or when their statuses are mixed.
+func planBulkEntriesState(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.NewValueKnown("published") {
+		return nil
+	}
+	published := d.Get("published").(bool)
+	for _, status := range d.Get("statuses").(map[string]interface{}) {
+		if bulkEntryStateChanges(published, status.(string)) {
+			return d.SetNewComputed("statuses")
+		}
+	}
+	return nil
+}
+
+// bulkEntryStateChanges returns whether an entry of status is published or unpublished to match published.
+func bulkEntryStateChanges(published bool, status string) bool {
+	if published {
+		return status == entityStatusDraft || status == entityStatusChanged
+	}
+	return status == entityStatusPublished || status == entityStatusChanged
+}
+
+func resourceEntriesUpdate(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, m *entriesClients) (diags diag.Diagnostics) {
+	oldDocument, _ := d.GetChange("entries_json")
+	var oldIDs []string
+	if oldEntries, err := expandBulkEntries(oldDocument.(string)); err == nil {
+		oldIDs = bulkEntryIDs(oldEntries)
+	}
+
+	diags = applyBulkEntries(ctx, d, env, m, oldIDs)
+	return append(diags, resourceEntriesRead(ctx, d, env, m)...)
+}
+
+func resourceEntriesDelete(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, m *entriesClients) (diags diag.Diagnostics) {
+	entries, err := expandBulkEntries(d.Get("entries_json").(string))
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	ids := bulkEntryIDs(entries)
+	current, err := listBulkEntries(ctx, m.entries, env, d.Get("content_type_id").(string), ids)
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+	for _, id := range ids {
+		if entry, ok := current[id]; ok {
+			diags = append(diags, deleteBulkEntry(ctx, m.entries, env, entry)...)
+		}
+	}
+	return
+}
+
+// applyBulkEntries deletes the entries of previousIDs which are no longer configured, creates or updates the
+// configured entries which differ from Contentful, and publishes or unpublishes them in bulk.
+// A failing entry does not stop the others.
+func applyBulkEntries(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, m *entriesClients, previousIDs []string) (diags diag.Diagnostics) {
+	contentTypeID := d.Get("content_type_id").(string)
+	published := d.Get("published").(bool)
+
+	entries, err := expandBulkEntries(d.Get("entries_json").(string))
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+	ids := bulkEntryIDs(entries)
+
+	configured := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		configured[id] = true
+	}
+	var removedIDs []string
+	for _, id := range previousIDs {
+		if !configured[id] {
+			removedIDs = append(removedIDs, id)
+		}
+	}
+
+	current, err := listBulkEntries(ctx, m.entries, env, contentTypeID, append(append([]string{}, ids...), removedIDs...))
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	for _, id := range removedIDs {
+		if entry, ok := current[id]; ok {
+			diags = append(diags, deleteBulkEntry(ctx, m.entries, env, entry)...)
+		}
+	}
+
+	for _, e := range entries {
+		entry, ok := current[e.ID]
+		if ok && equalBulkEntryFields(e, entry) {
+			continue
+		}
+		if !ok {
+			entry = &contentful.Entry{Sys: &contentful.Sys{ID: e.ID}}
+		}
+		entry.Fields = make(map[string]interface{}, len(e.Fields))
+		for id, values := range e.Fields {
+			entry.Fields[id] = values
+		}
+		if err := m.entries.Upsert(ctx, env, contentTypeID, entry); err != nil {
+			diags = append(diags, entityErrorToDiagnostic("Entry", e.ID, err)...)
+			continue
+		}
+		current[e.ID] = entry
+	}
+
+	action := "unpublish"
+	if published {
+		action = "publish"
+	}
+	var stateIDs []string
+	for _, id := range ids {
+		entry, ok := current[id]
+		if !ok {
+			continue
+		}
+		if bulkEntryStateChanges(published, entityStatus(entry.Sys)) {
+			stateIDs = append(stateIDs, id)
+		}
+	}
+	if len(stateIDs) > 0 {
+		_, actionDiags := runBulkActions(ctx, m.bulkActions, env.Sys.Space.Sys.ID, env.Sys.ID, action, newEntityLinks(stateIDs, nil))
+		diags = append(diags, actionDiags...)
+	}
+	return
+}
+
+// deleteBulkEntry unpublishes the entry if needed, and deletes it.
+func deleteBulkEntry(ctx context.Context, client ContentfulEntryClient, env *contentful.Environment, entry *contentful.Entry) diag.Diagnostics {
+	if status := entityStatus(entry.Sys); status == entityStatusPublished || status == entityStatusChanged {
+		if err := client.Unpublish(ctx, env, entry); err != nil {
+			return entityErrorToDiagnostic("Entry", entry.Sys.ID, err)
+		}
+	}
+	if err := client.Delete(ctx, env, entry.Sys.ID); err != nil {
+		if _, ok := err.(contentful.NotFoundError); ok {
+			return nil
+		}
+		return entityErrorToDiagnostic("Entry", entry.Sys.ID, err)
+	}
+	return nil
+}
+
+// listBulkEntries returns the entries of the content type with the given IDs by their IDs.
+func listBulkEntries(ctx context.Context, client ContentfulEntryListClient, env *contentful.Environment, contentTypeID string, ids []string) (map[string]*contentful.Entry, error) {
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	entries := make(map[string]*contentful.Entry, len(ids))
+	if len(ids) == 0 {
+		return entries, nil
+	}
+	for skip := 0; ; skip += contentTypeEntriesPageSize {
+		col, err := client.ListByContentType(ctx, env.Sys.Space.Sys.ID, env.Sys.ID, contentTypeID, skip, contentTypeEntriesPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range col.Items {
+			if entry.Sys != nil && wanted[entry.Sys.ID] {
+				entries[entry.Sys.ID] = entry
+			}
+		}
+		if skip+contentTypeEntriesPageSize >= col.Total {
+			return entries, nil
+		}
+	}
+}
+
+func bulkEntryIDs(entries []*bulkEntry) []string {
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+	return ids
+}
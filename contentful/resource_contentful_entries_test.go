@@ -0,0 +1,231 @@
+package contentful
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+func TestNormalizeBulkEntries(t *testing.T) {
+	tests := map[string]struct {
+		document string
+
+		expect    string
+		expectErr string
+	}{
+		"entries should be ordered by id": {
+			document: `[
+				{"id": "jp", "fields": {"name": {"en-US": "Japan"}, "code": {"en-US": "JP"}}},
+				{"id": "de"}
+			]`,
+			expect: `[{"id":"de","fields":{}},{"id":"jp","fields":{"code":{"en-US":"JP"},"name":{"en-US":"Japan"}}}]`,
+		},
+		"entries without id should fail": {
+			document:  `[{"fields": {}}]`,
+			expectErr: "[0] must have id",
+		},
+		"duplicated ids should fail": {
+			document:  `[{"id": "jp"}, {"id": "jp"}]`,
+			expectErr: "[1]: entry ID jp is used twice",
+		},
+		"other than an array should fail": {
+			document:  `{"id": "jp"}`,
+			expectErr: "json: cannot unmarshal object into Go value of type []*contentful.bulkEntry",
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			got, err := normalizeBulkEntries(tt.document)
+			var gotErr string
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tt.expectErr {
+				t.Fatalf("normalizeBulkEntries() error = %q, expect %q", gotErr, tt.expectErr)
+			}
+			if got != tt.expect {
+				t.Errorf("normalizeBulkEntries() = %s, expect %s", got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestResourceEntries(t *testing.T) {
+	ctx := context.Background()
+	client, env := newFakeCMAEnvironment(t)
+	m := &entriesClients{
+		entries:     newContentTypeEntriesClient(client),
+		bulkActions: &bulkActionsService{c: newCMAClient(client)},
+	}
+
+	ct := &ContentType{
+		Sys:    &contentful.Sys{ID: "country"},
+		Name:   "Country",
+		Fields: []*ContentTypeField{{Field: contentful.Field{ID: "name", Name: "Name", Type: "Symbol"}}, {Field: contentful.Field{ID: "population", Name: "Population", Type: "Integer"}}},
+	}
+	if err := upsertAndActivate(ctx, &contentTypesService{c: newCMAClient(client)}, env, ct); err != nil {
+		t.Fatal(err)
+	}
+
+	r := resourceContentfulEntries()
+	config := map[string]interface{}{
+		"space_id":        "space",
+		"env_id":          "master",
+		"content_type_id": "country",
+		"published":       true,
+		"entries_json": `[
+			{"id": "jp", "fields": {"name": {"en-US": "Japan"}, "population": {"en-US": 125000000}}},
+			{"id": "de", "fields": {"name": {"en-US": "Germany"}}},
+			{"id": "fr", "fields": {"name": {"en-US": "France"}}}
+		]`,
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := resourceEntriesCreate(ctx, d, env, m); diags.HasError() {
+		t.Fatalf("resourceEntriesCreate() diags = %v", diags)
+	}
+
+	expectStatuses := func(t *testing.T, expect map[string]string) {
+		t.Helper()
+		got := map[string]string{}
+		col, err := m.entries.ListByContentType(ctx, "space", "master", "country", 0, contentTypeEntriesPageSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, entry := range col.Items {
+			got[entry.Sys.ID] = entityStatus(entry.Sys)
+		}
+		if diff := cmp.Diff(expect, got); diff != "" {
+			t.Errorf("statuses of the entries diff (-expect, +got)\n%s", diff)
+		}
+	}
+	expectStatuses(t, map[string]string{"jp": entityStatusPublished, "de": entityStatusPublished, "fr": entityStatusPublished})
+
+	diff, err := r.SimpleDiff(ctx, d.State(), terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && len(diff.Attributes) > 0 {
+		t.Errorf("the configuration should have no diff after create, got %v", diff.Attributes)
+	}
+
+	// An editor changes an entry in the web app, which is detected by read.
+	de, err := m.entries.Get(ctx, env, "de")
+	if err != nil {
+		t.Fatal(err)
+	}
+	de.Fields["name"] = map[string]interface{}{"en-US": "Deutschland"}
+	if err := m.entries.Upsert(ctx, env, "country", de); err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceEntriesRead(ctx, d, env, m); diags.HasError() {
+		t.Fatalf("resourceEntriesRead() diags = %v", diags)
+	}
+	expectDocument := `[{"id":"de","fields":{"name":{"en-US":"Deutschland"}}},{"id":"fr","fields":{"name":{"en-US":"France"}}},{"id":"jp","fields":{"name":{"en-US":"Japan"},"population":{"en-US":125000000}}}]`
+	if got := d.Get("entries_json").(string); got != expectDocument {
+		t.Errorf("entries_json after read = %s, expect %s", got, expectDocument)
+	}
+	if !d.Get("published").(bool) {
+		t.Error("published after read should be true, because an entry with changes after the last publish is still published")
+	}
+	if got := d.Get("statuses.de"); got != entityStatusChanged {
+		t.Errorf("statuses.de after read = %v, expect %s", got, entityStatusChanged)
+	}
+
+	// Keeping the change plans to publish it again.
+	refreshedConfig := map[string]interface{}{}
+	for k, v := range config {
+		refreshedConfig[k] = v
+	}
+	refreshedConfig["entries_json"] = expectDocument
+	diff, err = r.SimpleDiff(ctx, d.State(), terraform.NewResourceConfigRaw(refreshedConfig), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Attributes["statuses.%"] == nil || !diff.Attributes["statuses.%"].NewComputed {
+		t.Errorf("statuses should be planned to change to publish the changed entry, got %v", diff)
+	}
+
+	// The changed entry is reverted, fr is removed and it is added.
+	config["entries_json"] = `[
+		{"id": "jp", "fields": {"name": {"en-US": "Japan"}, "population": {"en-US": 125000000}}},
+		{"id": "de", "fields": {"name": {"en-US": "Germany"}}},
+		{"id": "it", "fields": {"name": {"en-US": "Italy"}}}
+	]`
+	state := d.State()
+	diff, err = r.SimpleDiff(ctx, state, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jpVersion := func() int {
+		jp, err := m.entries.Get(ctx, env, "jp")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return jp.Sys.Version
+	}
+	versionBefore := jpVersion()
+	if diags := resourceEntriesUpdate(ctx, d, env, m); diags.HasError() {
+		t.Fatalf("resourceEntriesUpdate() diags = %v", diags)
+	}
+
+	expectStatuses(t, map[string]string{"jp": entityStatusPublished, "de": entityStatusPublished, "it": entityStatusPublished})
+	if got := jpVersion(); got != versionBefore {
+		t.Errorf("the unchanged entry should not be updated, but its version changed from %d to %d", versionBefore, got)
+	}
+	de, err = m.entries.Get(ctx, env, "de")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]interface{}{"name": map[string]interface{}{"en-US": "Germany"}}, de.Fields); diff != "" {
+		t.Errorf("fields of entry de after update diff (-expect, +got)\n%s", diff)
+	}
+
+	// The entries are unpublished, including one with changes after the last publish.
+	jp, err := m.entries.Get(ctx, env, "jp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jp.Fields["name"] = map[string]interface{}{"en-US": "Nippon"}
+	if err := m.entries.Upsert(ctx, env, "country", jp); err != nil {
+		t.Fatal(err)
+	}
+	config["published"] = false
+	config["entries_json"] = `[
+		{"id": "jp", "fields": {"name": {"en-US": "Nippon"}, "population": {"en-US": 125000000}}},
+		{"id": "de", "fields": {"name": {"en-US": "Germany"}}},
+		{"id": "it", "fields": {"name": {"en-US": "Italy"}}}
+	]`
+	if diags := resourceEntriesRead(ctx, d, env, m); diags.HasError() {
+		t.Fatalf("resourceEntriesRead() diags = %v", diags)
+	}
+	state = d.State()
+	diff, err = r.SimpleDiff(ctx, state, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Attributes["published"] == nil {
+		t.Errorf("published should be planned to change, got %v", diff)
+	}
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceEntriesUpdate(ctx, d, env, m); diags.HasError() {
+		t.Fatalf("resourceEntriesUpdate() diags = %v", diags)
+	}
+	expectStatuses(t, map[string]string{"jp": entityStatusDraft, "de": entityStatusDraft, "it": entityStatusDraft})
+
+	if diags := resourceEntriesDelete(ctx, d, env, m); diags.HasError() {
+		t.Fatalf("resourceEntriesDelete() diags = %v", diags)
+	}
+	expectStatuses(t, map[string]string{})
+}
@@ -2,43 +2,71 @@ package contentful
 
 import (
 	"context"
-
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	contentful "github.com/kitagry/contentful-go"
 )
 
 func resourceContentfulEntry() *schema.Resource {
 	return &schema.Resource{
+		Description:   "Manages an entry of a content type",
 		CreateContext: wrapEntry(resourceCreateEntry),
 		ReadContext:   wrapEntry(resourceReadEntry),
 		UpdateContext: wrapEntry(resourceUpdateEntry),
 		DeleteContext: wrapEntry(resourceDeleteEntry),
+		Importer:      importEnvironmentEntity("entry_id"),
+		CustomizeDiff: customdiff.All(setProviderDefaults, planRepublish, checkEntryFields),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"entry_id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the entry",
 			},
 			"version": {
-				Type:     schema.TypeInt,
-				Computed: true,
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The current version of the entry, which increases on every change",
 			},
 			"space_id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the space. Defaults to `space_id` of the provider",
 			},
 			"env_id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the environment. Defaults to `environment_id` of the provider",
 			},
 			"contenttype_id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the content type of the entry",
 			},
 			"locale": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The code of the locale of the entry",
 			},
 			"field": {
 				Type:     schema.TypeList,
@@ -46,53 +74,73 @@ func resourceContentfulEntry() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"id": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the field",
 						},
 						"content": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The value of the field. The values of fields which are not `Symbol`, `Text` or `Date` are JSON, such as `10`, `true` or `{\"sys\":{\"type\":\"Link\",\"linkType\":\"Entry\",\"id\":\"...\"}}`",
 						},
 						"locale": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The code of the locale of the value",
 						},
 					},
 				},
+				Description: "The values of the fields of the entry. The fields are validated against the content type and the locales of the environment during plan, and again before the entry is written: the field IDs, the locales, the localization of the fields, the types of the contents and, for a published entry, the required fields. The plan skips the validation while the content type is not known or does not exist yet. Values changed outside of Terraform are refreshed into the blocks",
 			},
 			"published": {
-				Type:     schema.TypeBool,
-				Required: true,
+				Type:        schema.TypeBool,
+				Required:    true,
+				Description: "Whether the entry is published. An entry changed after the last publish is published again",
 			},
 			"archived": {
-				Type:     schema.TypeBool,
-				Required: true,
+				Type:        schema.TypeBool,
+				Required:    true,
+				Description: "Whether the entry is archived. An archived entry cannot be published",
+			},
+			"published_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The version of the entry when it was last published",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The publication status of the entry: `draft`, `published`, `changed` or `archived`",
 			},
 		},
 	}
 }
 
-func wrapEntry(f func(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, entryClient ContentfulEntryClient) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+func wrapEntry(f func(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, entryClient ContentfulEntryClient, checker *entryFieldsChecker) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-		client := m.(*contentful.Client)
+		meta := m.(*providerMeta)
+		client := meta.client
 		spaceID := d.Get("space_id").(string)
 		envID := d.Get("env_id").(string)
-		env, err := client.Environments.Get(ctx, spaceID, envID)
+		env, err := meta.environments.Get(ctx, client.Environments, spaceID, envID)
 		if err != nil {
 			diags = append(diags, contentfulErrorToDiagnostic(err)...)
 			return
 		}
-		return f(ctx, d, env, client.Entries)
+		return f(ctx, d, env, client.Entries, newEntryFieldsChecker(client))
 	}
 }
 
-func resourceCreateEntry(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEntryClient) (diags diag.Diagnostics) {
-	fieldProperties := map[string]interface{}{}
-	rawField := d.Get("field").([]interface{})
-	for i := 0; i < len(rawField); i++ {
-		field := rawField[i].(map[string]interface{})
-		fieldProperties[field["id"].(string)] = map[string]interface{}{}
-		fieldProperties[field["id"].(string)].(map[string]interface{})[field["locale"].(string)] = field["content"].(string)
+func resourceCreateEntry(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEntryClient, checker *entryFieldsChecker) (diags diag.Diagnostics) {
+	ct, diags := checker.check(ctx, env, d)
+	if diags.HasError() {
+		return
+	}
+
+	fieldProperties, err := expandEntryFields(d.Get("field").([]interface{}), ct)
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
 	}
 
 	entry := &contentful.Entry{
@@ -103,28 +151,18 @@ func resourceCreateEntry(ctx context.Context, d *schema.ResourceData, env *conte
 		},
 	}
 
-	err := client.Upsert(ctx, env, d.Get("contenttype_id").(string), entry)
+	err = client.Upsert(ctx, env, d.Get("contenttype_id").(string), entry)
 	if err != nil {
 		diags = append(diags, contentfulErrorToDiagnostic(err)...)
 		return
 	}
 
-	if err := setEntryProperties(d, entry); err != nil {
-		diags = append(diags, contentfulErrorToDiagnostic(err)...)
-		return
-	}
-
 	d.SetId(entry.Sys.ID)
 
-	if err := setEntryState(ctx, d, env, client); err != nil {
-		diags = append(diags, contentfulErrorToDiagnostic(err)...)
-		return
-	}
-
-	return
+	return setEntryState(ctx, d, env, client)
 }
 
-func resourceUpdateEntry(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEntryClient) (diags diag.Diagnostics) {
+func resourceUpdateEntry(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEntryClient, checker *entryFieldsChecker) (diags diag.Diagnostics) {
 	entryID := d.Id()
 	defer func() {
 		if diags.HasError() {
@@ -132,6 +170,11 @@ func resourceUpdateEntry(ctx context.Context, d *schema.ResourceData, env *conte
 		}
 	}()
 
+	ct, diags := checker.check(ctx, env, d)
+	if diags.HasError() {
+		return
+	}
+
 	// lookup the entry
 	entry, err := client.Get(ctx, env, entryID)
 	if err != nil {
@@ -139,12 +182,10 @@ func resourceUpdateEntry(ctx context.Context, d *schema.ResourceData, env *conte
 		return
 	}
 
-	fieldProperties := map[string]interface{}{}
-	rawField := d.Get("field").([]interface{})
-	for i := 0; i < len(rawField); i++ {
-		field := rawField[i].(map[string]interface{})
-		fieldProperties[field["id"].(string)] = map[string]interface{}{}
-		fieldProperties[field["id"].(string)].(map[string]interface{})[field["locale"].(string)] = field["content"].(string)
+	fieldProperties, err := expandEntryFields(d.Get("field").([]interface{}), ct)
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
 	}
 
 	entry.Fields = fieldProperties
@@ -158,40 +199,25 @@ func resourceUpdateEntry(ctx context.Context, d *schema.ResourceData, env *conte
 
 	d.SetId(entry.Sys.ID)
 
-	if err := setEntryProperties(d, entry); err != nil {
-		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+	return setEntryState(ctx, d, env, client)
+}
+
+func setEntryState(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEntryClient) (diags diag.Diagnostics) {
+	stateClient := &entryStateClient{client: client, env: env, id: d.Id()}
+
+	diags = changeEntityState(ctx, stateClient, d.Get("published").(bool), d.Get("archived").(bool))
+	if diags.HasError() {
 		return
 	}
 
-	if err := setEntryState(ctx, d, env, client); err != nil {
+	if err := setEntryProperties(d, stateClient.entry); err != nil {
 		diags = append(diags, contentfulErrorToDiagnostic(err)...)
 		return
 	}
-
 	return
 }
 
-func setEntryState(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEntryClient) (err error) {
-	entryID := d.Id()
-
-	entry, _ := client.Get(ctx, env, entryID)
-
-	if d.Get("published").(bool) && entry.Sys.PublishedAt == "" {
-		err = client.Publish(ctx, env, entry)
-	} else if !d.Get("published").(bool) && entry.Sys.PublishedAt != "" {
-		err = client.Unpublish(ctx, env, entry)
-	}
-
-	if d.Get("archived").(bool) && entry.Sys.ArchivedAt == "" {
-		err = client.Archive(ctx, env, entry)
-	} else if !d.Get("archived").(bool) && entry.Sys.ArchivedAt != "" {
-		err = client.Unarchive(ctx, env, entry)
-	}
-
-	return err
-}
-
-func resourceReadEntry(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEntryClient) (diags diag.Diagnostics) {
+func resourceReadEntry(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEntryClient, checker *entryFieldsChecker) (diags diag.Diagnostics) {
 	entryID := d.Id()
 
 	entry, err := client.Get(ctx, env, entryID)
@@ -213,7 +239,7 @@ func resourceReadEntry(ctx context.Context, d *schema.ResourceData, env *content
 	return
 }
 
-func resourceDeleteEntry(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEntryClient) (diags diag.Diagnostics) {
+func resourceDeleteEntry(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEntryClient, checker *entryFieldsChecker) (diags diag.Diagnostics) {
 	entryID := d.Id()
 
 	_, err := client.Get(ctx, env, entryID)
@@ -239,9 +265,466 @@ func setEntryProperties(d *schema.ResourceData, entry *contentful.Entry) (err er
 		return err
 	}
 
+	if err = d.Set("published_version", entry.Sys.PublishedVersion); err != nil {
+		return err
+	}
+
+	status := entityStatus(entry.Sys)
+	if err = d.Set("status", status); err != nil {
+		return err
+	}
+
+	// An entity with changes after the last publish is still published. planRepublish publishes it again.
+	if err = d.Set("published", hasPublishedVersion(entry.Sys)); err != nil {
+		return err
+	}
+
+	if err = d.Set("archived", status == entityStatusArchived); err != nil {
+		return err
+	}
+
 	if err = d.Set("contenttype_id", entry.Sys.ContentType.Sys.ID); err != nil {
 		return err
 	}
 
+	fields, err := flattenEntryFields(entry.Fields, d.Get("field").([]interface{}))
+	if err != nil {
+		return err
+	}
+	if err = d.Set("field", fields); err != nil {
+		return err
+	}
+
 	return err
 }
+
+// expandEntryFields converts field blocks into the fields of an entry, which map each field ID to its values by
+// locale. The contents of fields of text types are sent as they are, and those of other types are decoded from JSON.
+func expandEntryFields(rawFields []interface{}, ct *ContentType) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	for _, raw := range rawFields {
+		field := raw.(map[string]interface{})
+		id := field["id"].(string)
+		locale := field["locale"].(string)
+
+		var fieldType string
+		if f, ok := findFieldByAPIName(ct.Fields, id); ok {
+			fieldType = f.Type
+		}
+		value, err := expandEntryFieldContent(fieldType, field["content"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("the content of field %s in locale %s is not JSON: %w", id, locale, err)
+		}
+
+		if _, ok := fields[id]; !ok {
+			fields[id] = map[string]interface{}{}
+		}
+		fields[id].(map[string]interface{})[locale] = value
+	}
+	return fields, nil
+}
+
+// expandEntryFieldContent returns the value of content for a field of fieldType.
+func expandEntryFieldContent(fieldType, content string) (interface{}, error) {
+	switch fieldType {
+	case contentful.FieldTypeSymbol, contentful.FieldTypeText, contentful.FieldTypeDate, "":
+		return content, nil
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(content), &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// flattenEntryFields converts the fields of an entry into field blocks. Strings are kept as they are, and other
+// values are encoded as JSON with sorted keys, unless the content of the current block is JSON of the same value.
+// The blocks of current keep their order, so that a configuration in any order has no diff, and the other values
+// follow ordered by ID and then locale.
+func flattenEntryFields(fields map[string]interface{}, current []interface{}) ([]interface{}, error) {
+	currentContents := make(map[string]string, len(current))
+	for _, raw := range current {
+		if field, ok := raw.(map[string]interface{}); ok {
+			currentContents[fmt.Sprintf("%s\t%s", field["id"], field["locale"])], _ = field["content"].(string)
+		}
+	}
+
+	blocks := make(map[string]map[string]interface{})
+	var keys []string
+	for id, v := range fields {
+		values, _ := v.(map[string]interface{})
+		for locale, value := range values {
+			key := id + "\t" + locale
+			content, ok := value.(string)
+			if !ok {
+				b, err := json.Marshal(value)
+				if err != nil {
+					return nil, fmt.Errorf("failed to encode field %s in locale %s: %w", id, locale, err)
+				}
+				content = string(b)
+				if isEquivalentJSON(currentContents[key], content) {
+					content = currentContents[key]
+				}
+			}
+			blocks[key] = map[string]interface{}{"id": id, "locale": locale, "content": content}
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	result := make([]interface{}, 0, len(keys))
+	for _, raw := range current {
+		field, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key := fmt.Sprintf("%s\t%s", field["id"], field["locale"])
+		if block, ok := blocks[key]; ok {
+			result = append(result, block)
+			delete(blocks, key)
+		}
+	}
+	for _, key := range keys {
+		if block, ok := blocks[key]; ok {
+			result = append(result, block)
+		}
+	}
+	return result, nil
+}
+
+// isEquivalentJSON returns whether a and b are JSON of the same value.
+func isEquivalentJSON(a, b string) bool {
+	var va, vb interface{}
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// entryField is a field block of contentful_entry. contentKnown is false while the content is not known during plan.
+type entryField struct {
+	id           string
+	locale       string
+	content      string
+	contentKnown bool
+}
+
+func newEntryFields(rawFields []interface{}, contentKnown func(i int) bool) []entryField {
+	fields := make([]entryField, 0, len(rawFields))
+	for i, raw := range rawFields {
+		field := raw.(map[string]interface{})
+		fields = append(fields, entryField{
+			id:           field["id"].(string),
+			locale:       field["locale"].(string),
+			content:      field["content"].(string),
+			contentKnown: contentKnown(i),
+		})
+	}
+	return fields
+}
+
+// entryLocale is a locale of the environment of an entry.
+type entryLocale struct {
+	isDefault bool
+	optional  bool
+}
+
+// checkEntryFields validates the fields against the content type and the locales of the environment, so that
+// mistakes are reported during plan rather than by the API. Entries of content types or environments which are not
+// known or do not exist yet are validated before they are written.
+func checkEntryFields(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	meta, ok := m.(*providerMeta)
+	if !ok {
+		return nil
+	}
+	for _, key := range []string{"space_id", "env_id", "contenttype_id", "locale", "field", "published"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	env, err := meta.environments.Get(ctx, meta.client.Environments, d.Get("space_id").(string), d.Get("env_id").(string))
+	if _, ok := err.(contentful.NotFoundError); ok {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	ct, locales, err := newEntryFieldsChecker(meta.client).load(ctx, env, d.Get("contenttype_id").(string))
+	if _, ok := err.(contentful.NotFoundError); ok {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	rawFields := d.Get("field").([]interface{})
+	for i := range rawFields {
+		if !d.NewValueKnown(fmt.Sprintf("field.%d.id", i)) || !d.NewValueKnown(fmt.Sprintf("field.%d.locale", i)) {
+			return nil
+		}
+	}
+	fields := newEntryFields(rawFields, func(i int) bool {
+		return d.NewValueKnown(fmt.Sprintf("field.%d.content", i))
+	})
+	diags := validateEntryFields(ct, locales, d.Get("locale").(string), fields, d.Get("published").(bool))
+	if len(diags) > 0 {
+		// CustomizeDiff reports a single error, so the problems are listed with their paths.
+		problems := make([]string, 0, len(diags))
+		for _, problem := range diags {
+			problems = append(problems, fmt.Sprintf("%s: %s", formatAttributePath(problem.AttributePath), problem.Summary))
+		}
+		return fmt.Errorf("the fields do not match content type %s:\n%s", ct.Sys.ID, strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// formatAttributePath formats path like field.0.content.
+func formatAttributePath(path cty.Path) string {
+	steps := make([]string, 0, len(path))
+	for _, step := range path {
+		switch step := step.(type) {
+		case cty.GetAttrStep:
+			steps = append(steps, step.Name)
+		case cty.IndexStep:
+			if step.Key.Type() == cty.Number {
+				i, _ := step.Key.AsBigFloat().Int64()
+				steps = append(steps, strconv.FormatInt(i, 10))
+			} else {
+				steps = append(steps, step.Key.AsString())
+			}
+		}
+	}
+	return strings.Join(steps, ".")
+}
+
+// entryFieldsChecker validates the fields of an entry against its content type and the locales of its environment
+// before the entry is written, since checkEntryFields skips content types which do not exist during plan.
+type entryFieldsChecker struct {
+	contentTypes ContentfulContentTypeClient
+	entities     ContentfulEnvironmentEntityClient
+}
+
+func newEntryFieldsChecker(client *contentful.Client) *entryFieldsChecker {
+	return &entryFieldsChecker{
+		contentTypes: &contentTypesService{c: newCMAClient(client)},
+		entities:     &environmentEntitiesService{c: newCMAClient(client)},
+	}
+}
+
+// load returns the content type and the locales of env by their codes.
+func (c *entryFieldsChecker) load(ctx context.Context, env *contentful.Environment, contentTypeID string) (*ContentType, map[string]entryLocale, error) {
+	ct, err := c.contentTypes.Get(ctx, env, contentTypeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	docs, err := c.entities.List(ctx, env, "locales")
+	if err != nil {
+		return nil, nil, err
+	}
+	locales := make(map[string]entryLocale, len(docs))
+	for _, doc := range docs {
+		locales[stringValue(doc["code"])] = entryLocale{isDefault: doc["default"] == true, optional: doc["optional"] == true}
+	}
+	return ct, locales, nil
+}
+
+// check returns the content type of the entry, which the contents of its fields are decoded by.
+func (c *entryFieldsChecker) check(ctx context.Context, env *contentful.Environment, d *schema.ResourceData) (ct *ContentType, diags diag.Diagnostics) {
+	ct, locales, err := c.load(ctx, env, d.Get("contenttype_id").(string))
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	fields := newEntryFields(d.Get("field").([]interface{}), func(int) bool { return true })
+	diags = validateEntryFields(ct, locales, d.Get("locale").(string), fields, d.Get("published").(bool))
+	return
+}
+
+// entryDateLayouts are the ISO 8601 forms Contentful accepts for Date fields.
+var entryDateLayouts = []string{
+	"2006-01-02",
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04Z07:00",
+	time.RFC3339,
+}
+
+// validateEntryFields returns a diagnostic for each problem of the fields, which points at the offending attribute.
+// The contents which are not known yet are not validated.
+func validateEntryFields(ct *ContentType, locales map[string]entryLocale, entryLocaleCode string, fields []entryField, published bool) (diags diag.Diagnostics) {
+	problem := func(path cty.Path, format string, a ...interface{}) {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf(format, a...),
+			AttributePath: path,
+		})
+	}
+	fieldPath := func(i int, attr string) cty.Path {
+		path := cty.Path{cty.GetAttrStep{Name: "field"}, cty.IndexStep{Key: cty.NumberIntVal(int64(i))}}
+		if attr != "" {
+			path = path.GetAttr(attr)
+		}
+		return path
+	}
+
+	if _, ok := locales[entryLocaleCode]; !ok {
+		problem(cty.GetAttrPath("locale"), "locale %q does not exist in the environment", entryLocaleCode)
+	}
+
+	set := map[string]bool{}
+	for i, field := range fields {
+		f, ok := findFieldByAPIName(ct.Fields, field.id)
+		if !ok {
+			problem(fieldPath(i, "id"), "content type %s has no field %q", ct.Sys.ID, field.id)
+			continue
+		}
+
+		locale, ok := locales[field.locale]
+		switch {
+		case !ok:
+			problem(fieldPath(i, "locale"), "locale %q does not exist in the environment", field.locale)
+		case !f.Localized && !locale.isDefault:
+			problem(fieldPath(i, "locale"), "field %q is not localized, so it only has a value in the default locale", field.id)
+		}
+
+		key := field.id + "\t" + field.locale
+		if set[key] {
+			problem(fieldPath(i, ""), "field %q is set twice in locale %q", field.id, field.locale)
+		}
+		set[key] = true
+
+		if !field.contentKnown {
+			continue
+		}
+		switch f.Type {
+		case contentful.FieldTypeSymbol:
+			if n := utf8.RuneCountInString(field.content); n > 256 {
+				problem(fieldPath(i, "content"), "field %q is a Symbol of up to 256 characters, but the content has %d", field.id, n)
+			}
+		case contentful.FieldTypeText:
+			if n := utf8.RuneCountInString(field.content); n > 50000 {
+				problem(fieldPath(i, "content"), "field %q is a Text of up to 50000 characters, but the content has %d", field.id, n)
+			}
+		case contentful.FieldTypeDate:
+			if !isEntryDate(field.content) {
+				problem(fieldPath(i, "content"), "field %q is a Date, but %q is not an ISO 8601 date", field.id, field.content)
+			}
+		default:
+			var value interface{}
+			if err := json.Unmarshal([]byte(field.content), &value); err != nil || !isEntryFieldValue(f.Type, f.LinkType, f.Items, value) {
+				problem(fieldPath(i, "content"), "field %q is of type %s, so the content should be JSON such as %s", field.id, f.Type, entryFieldExample(f.Type, f.LinkType, f.Items))
+			}
+		}
+	}
+
+	// Contentful only enforces required fields on publish, in the default locale and, for localized fields,
+	// in the locales which are not optional.
+	if published {
+		for _, f := range ct.Fields {
+			if !f.Required || f.Omitted || f.Disabled {
+				continue
+			}
+			for _, code := range sortedEntryLocales(locales) {
+				locale := locales[code]
+				if !locale.isDefault && (!f.Localized || locale.optional) {
+					continue
+				}
+				if !set[apiNameOf(f)+"\t"+code] {
+					problem(cty.GetAttrPath("field"), "required field %q has no value in locale %q, so the entry cannot be published", apiNameOf(f), code)
+				}
+			}
+		}
+	}
+	return diags
+}
+
+// isEntryFieldValue returns whether value decoded from JSON is a value of a field of fieldType. The items of an
+// Array are values of the type of items. Values of unknown types are left to the API.
+func isEntryFieldValue(fieldType, linkType string, items *contentful.FieldTypeArrayItem, value interface{}) bool {
+	switch fieldType {
+	case contentful.FieldTypeSymbol, contentful.FieldTypeText, contentful.FieldTypeDate:
+		_, ok := value.(string)
+		return ok
+	case contentful.FieldTypeInteger:
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "Number":
+		_, ok := value.(float64)
+		return ok
+	case contentful.FieldTypeBoolean:
+		_, ok := value.(bool)
+		return ok
+	case contentful.FieldTypeLocation:
+		location, _ := value.(map[string]interface{})
+		_, lat := location["lat"].(float64)
+		_, lon := location["lon"].(float64)
+		return lat && lon
+	case contentful.FieldTypeLink:
+		link, _ := value.(map[string]interface{})
+		sys, _ := link["sys"].(map[string]interface{})
+		return sys["type"] == "Link" && (linkType == "" || sys["linkType"] == linkType)
+	case contentful.FieldTypeArray:
+		values, ok := value.([]interface{})
+		if !ok {
+			return false
+		}
+		if items == nil {
+			return true
+		}
+		for _, v := range values {
+			if !isEntryFieldValue(items.Type, items.LinkType, nil, v) {
+				return false
+			}
+		}
+		return true
+	case contentful.FieldTypeObject, contentful.FieldTypeRichText:
+		_, ok := value.(map[string]interface{})
+		return ok
+	}
+	return true
+}
+
+// entryFieldExample returns an example of the JSON content of a field of fieldType.
+func entryFieldExample(fieldType, linkType string, items *contentful.FieldTypeArrayItem) string {
+	switch fieldType {
+	case contentful.FieldTypeSymbol, contentful.FieldTypeText:
+		return `"a"`
+	case contentful.FieldTypeInteger, "Number":
+		return "10"
+	case contentful.FieldTypeBoolean:
+		return "true"
+	case contentful.FieldTypeLocation:
+		return `{"lat":35.6,"lon":139.7}`
+	case contentful.FieldTypeLink:
+		if linkType == "" {
+			linkType = "Entry"
+		}
+		return fmt.Sprintf(`{"sys":{"type":"Link","linkType":"%s","id":"<%s ID>"}}`, linkType, strings.ToLower(linkType))
+	case contentful.FieldTypeArray:
+		if items != nil {
+			return "[" + entryFieldExample(items.Type, items.LinkType, nil) + "]"
+		}
+		return `["a","b"]`
+	}
+	return "{}"
+}
+
+func isEntryDate(s string) bool {
+	for _, layout := range entryDateLayouts {
+		if _, err := time.Parse(layout, s); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedEntryLocales(locales map[string]entryLocale) []string {
+	codes := make([]string, 0, len(locales))
+	for code := range locales {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
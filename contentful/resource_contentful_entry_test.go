@@ -0,0 +1,401 @@
+package contentful
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+func TestValidateEntryFields(t *testing.T) {
+	ct := &ContentType{
+		Sys: &contentful.Sys{ID: "post"},
+		Fields: []*ContentTypeField{
+			{Field: contentful.Field{ID: "title", Type: "Symbol", Localized: true, Required: true}},
+			{Field: contentful.Field{ID: "internalBody", Type: "Text"}, APIName: "body"},
+			{Field: contentful.Field{ID: "date", Type: "Date"}},
+			{Field: contentful.Field{ID: "views", Type: "Integer"}},
+			{Field: contentful.Field{ID: "featured", Type: "Boolean"}},
+			{Field: contentful.Field{ID: "place", Type: "Location"}},
+			{Field: contentful.Field{ID: "author", Type: "Link", LinkType: "Entry"}},
+			{Field: contentful.Field{ID: "tags", Type: "Array", Items: &contentful.FieldTypeArrayItem{Type: "Symbol"}}},
+		},
+	}
+	locales := map[string]entryLocale{
+		"en-US": {isDefault: true},
+		"de":    {},
+		"fr":    {optional: true},
+	}
+
+	tests := map[string]struct {
+		locale    string
+		fields    []entryField
+		published bool
+
+		expect []string
+	}{
+		"valid fields should pass": {
+			locale: "en-US",
+			fields: []entryField{
+				{id: "title", locale: "en-US", content: "Hello", contentKnown: true},
+				{id: "title", locale: "de", content: "Hallo", contentKnown: true},
+				{id: "body", locale: "en-US", content: "Body", contentKnown: true},
+				{id: "date", locale: "en-US", content: "2022-01-02T10:00", contentKnown: true},
+				{id: "views", locale: "en-US", content: "10", contentKnown: true},
+				{id: "featured", locale: "en-US", content: "true", contentKnown: true},
+				{id: "place", locale: "en-US", content: `{"lat":35.6,"lon":139.7}`, contentKnown: true},
+				{id: "author", locale: "en-US", content: `{"sys":{"type":"Link","linkType":"Entry","id":"alice"}}`, contentKnown: true},
+				{id: "tags", locale: "en-US", content: `["news"]`, contentKnown: true},
+			},
+			published: true,
+		},
+		"unknown fields and locales should fail": {
+			locale: "ja",
+			fields: []entryField{
+				{id: "internalBody", locale: "en-US", content: "Body", contentKnown: true},
+				{id: "title", locale: "ja", content: "Hello", contentKnown: true},
+			},
+			expect: []string{
+				`locale: locale "ja" does not exist in the environment`,
+				`field.0.id: content type post has no field "internalBody"`,
+				`field.1.locale: locale "ja" does not exist in the environment`,
+			},
+		},
+		"fields which are not localized should only be set in the default locale": {
+			locale: "en-US",
+			fields: []entryField{
+				{id: "body", locale: "de", content: "Body", contentKnown: true},
+			},
+			expect: []string{
+				`field.0.locale: field "body" is not localized, so it only has a value in the default locale`,
+			},
+		},
+		"fields set twice should fail": {
+			locale: "en-US",
+			fields: []entryField{
+				{id: "body", locale: "en-US", content: "Body", contentKnown: true},
+				{id: "body", locale: "en-US", content: "Other", contentKnown: true},
+			},
+			expect: []string{
+				`field.1: field "body" is set twice in locale "en-US"`,
+			},
+		},
+		"contents which do not match the type should fail": {
+			locale: "en-US",
+			fields: []entryField{
+				{id: "title", locale: "en-US", content: strings.Repeat("a", 257), contentKnown: true},
+				{id: "date", locale: "en-US", content: "tomorrow", contentKnown: true},
+				{id: "views", locale: "en-US", content: "ten", contentKnown: true},
+			},
+			expect: []string{
+				`field.0.content: field "title" is a Symbol of up to 256 characters, but the content has 257`,
+				`field.1.content: field "date" is a Date, but "tomorrow" is not an ISO 8601 date`,
+				`field.2.content: field "views" is of type Integer, so the content should be JSON such as 10`,
+			},
+		},
+		"JSON values which do not match the type should fail": {
+			locale: "en-US",
+			fields: []entryField{
+				{id: "views", locale: "en-US", content: `"abc"`, contentKnown: true},
+				{id: "featured", locale: "de", content: `{"a":1}`, contentKnown: true},
+				{id: "place", locale: "en-US", content: `{"lat":35.6}`, contentKnown: true},
+				{id: "author", locale: "en-US", content: "10", contentKnown: true},
+				{id: "tags", locale: "en-US", content: "[1]", contentKnown: true},
+				{id: "views", locale: "de", content: "1.5", contentKnown: true},
+			},
+			expect: []string{
+				`field.0.content: field "views" is of type Integer, so the content should be JSON such as 10`,
+				`field.1.locale: field "featured" is not localized, so it only has a value in the default locale`,
+				`field.1.content: field "featured" is of type Boolean, so the content should be JSON such as true`,
+				`field.2.content: field "place" is of type Location, so the content should be JSON such as {"lat":35.6,"lon":139.7}`,
+				`field.3.content: field "author" is of type Link, so the content should be JSON such as {"sys":{"type":"Link","linkType":"Entry","id":"<entry ID>"}}`,
+				`field.4.content: field "tags" is of type Array, so the content should be JSON such as ["a"]`,
+				`field.5.locale: field "views" is not localized, so it only has a value in the default locale`,
+				`field.5.content: field "views" is of type Integer, so the content should be JSON such as 10`,
+			},
+		},
+		"links to another type of entities should fail": {
+			locale: "en-US",
+			fields: []entryField{
+				{id: "author", locale: "en-US", content: `{"sys":{"type":"Link","linkType":"Asset","id":"logo"}}`, contentKnown: true},
+			},
+			expect: []string{
+				`field.0.content: field "author" is of type Link, so the content should be JSON such as {"sys":{"type":"Link","linkType":"Entry","id":"<entry ID>"}}`,
+			},
+		},
+		"contents which are not known should not be checked": {
+			locale: "en-US",
+			fields: []entryField{
+				{id: "views", locale: "en-US", content: ""},
+			},
+		},
+		"required fields should be set in the required locales of a published entry": {
+			locale: "en-US",
+			fields: []entryField{
+				{id: "title", locale: "en-US", content: "Hello", contentKnown: true},
+			},
+			published: true,
+			expect: []string{
+				`field: required field "title" has no value in locale "de", so the entry cannot be published`,
+			},
+		},
+		"required fields should not be checked for a draft": {
+			locale:    "en-US",
+			fields:    []entryField{},
+			published: false,
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			var got []string
+			for _, d := range validateEntryFields(ct, locales, tt.locale, tt.fields, tt.published) {
+				got = append(got, formatAttributePath(d.AttributePath)+": "+d.Summary)
+			}
+			if diff := cmp.Diff(tt.expect, got); diff != "" {
+				t.Errorf("validateEntryFields result diff (-expect, +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFlattenEntryFields(t *testing.T) {
+	fields := map[string]interface{}{
+		"title":    map[string]interface{}{"en-US": "Hello", "de": "Hallo"},
+		"location": map[string]interface{}{"en-US": map[string]interface{}{"lon": 139.7, "lat": 35.6}},
+		"tags":     map[string]interface{}{"en-US": []interface{}{"news", "tech"}},
+	}
+
+	tests := map[string]struct {
+		current []interface{}
+
+		expect []interface{}
+	}{
+		"fields should be ordered by id and then locale": {
+			expect: []interface{}{
+				map[string]interface{}{"id": "location", "locale": "en-US", "content": `{"lat":35.6,"lon":139.7}`},
+				map[string]interface{}{"id": "tags", "locale": "en-US", "content": `["news","tech"]`},
+				map[string]interface{}{"id": "title", "locale": "de", "content": "Hallo"},
+				map[string]interface{}{"id": "title", "locale": "en-US", "content": "Hello"},
+			},
+		},
+		"fields in the current blocks should keep their order": {
+			current: []interface{}{
+				map[string]interface{}{"id": "title", "locale": "en-US", "content": "Old"},
+				map[string]interface{}{"id": "removed", "locale": "en-US", "content": "Removed"},
+				map[string]interface{}{"id": "tags", "locale": "en-US", "content": `["news"]`},
+			},
+			expect: []interface{}{
+				map[string]interface{}{"id": "title", "locale": "en-US", "content": "Hello"},
+				map[string]interface{}{"id": "tags", "locale": "en-US", "content": `["news","tech"]`},
+				map[string]interface{}{"id": "location", "locale": "en-US", "content": `{"lat":35.6,"lon":139.7}`},
+				map[string]interface{}{"id": "title", "locale": "de", "content": "Hallo"},
+			},
+		},
+		"current JSON of the same value should be kept": {
+			current: []interface{}{
+				map[string]interface{}{"id": "location", "locale": "en-US", "content": `{ "lon": 139.7, "lat": 35.6 }`},
+				map[string]interface{}{"id": "tags", "locale": "en-US", "content": `[ "news", "tech" ]`},
+			},
+			expect: []interface{}{
+				map[string]interface{}{"id": "location", "locale": "en-US", "content": `{ "lon": 139.7, "lat": 35.6 }`},
+				map[string]interface{}{"id": "tags", "locale": "en-US", "content": `[ "news", "tech" ]`},
+				map[string]interface{}{"id": "title", "locale": "de", "content": "Hallo"},
+				map[string]interface{}{"id": "title", "locale": "en-US", "content": "Hello"},
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			got, err := flattenEntryFields(fields, tt.current)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.expect, got); diff != "" {
+				t.Errorf("flattenEntryFields result diff (-expect, +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResourceReadEntry_fieldDrift(t *testing.T) {
+	ctx := context.Background()
+	client, env := newFakeCMAEnvironment(t)
+
+	ct := &ContentType{
+		Sys:    &contentful.Sys{ID: "post"},
+		Name:   "Post",
+		Fields: []*ContentTypeField{{Field: contentful.Field{ID: "title", Name: "Title", Type: "Symbol"}}, {Field: contentful.Field{ID: "body", Name: "Body", Type: "Text"}}},
+	}
+	if err := upsertAndActivate(ctx, &contentTypesService{c: newCMAClient(client)}, env, ct); err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceContentfulEntry().Schema, map[string]interface{}{
+		"space_id":       "space",
+		"env_id":         "master",
+		"entry_id":       "hello",
+		"contenttype_id": "post",
+		"locale":         "en-US",
+		"published":      false,
+		"archived":       false,
+		"field": []interface{}{
+			map[string]interface{}{"id": "title", "locale": "en-US", "content": "Hello"},
+		},
+	})
+	if diags := resourceCreateEntry(ctx, d, env, client.Entries, newEntryFieldsChecker(client)); diags.HasError() {
+		t.Fatalf("resourceCreateEntry() diags = %v", diags)
+	}
+
+	// An editor changes the title and adds a body in the web app.
+	entry, err := client.Entries.Get(ctx, env, "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry.Fields = map[string]interface{}{
+		"title": map[string]interface{}{"en-US": "Edited"},
+		"body":  map[string]interface{}{"en-US": "Added"},
+	}
+	if err := client.Entries.Upsert(ctx, env, "post", entry); err != nil {
+		t.Fatal(err)
+	}
+
+	if diags := resourceReadEntry(ctx, d, env, client.Entries, newEntryFieldsChecker(client)); diags.HasError() {
+		t.Fatalf("resourceReadEntry() diags = %v", diags)
+	}
+	expect := []interface{}{
+		map[string]interface{}{"id": "title", "locale": "en-US", "content": "Edited"},
+		map[string]interface{}{"id": "body", "locale": "en-US", "content": "Added"},
+	}
+	if diff := cmp.Diff(expect, d.Get("field")); diff != "" {
+		t.Errorf("field after read diff (-expect, +got)\n%s", diff)
+	}
+}
+
+func TestCheckEntryFields(t *testing.T) {
+	ctx := context.Background()
+	client, env := newFakeCMAEnvironment(t)
+	contentTypes := &contentTypesService{c: newCMAClient(client)}
+
+	r := resourceContentfulEntry()
+	config := map[string]interface{}{
+		"space_id":       "space",
+		"env_id":         "master",
+		"entry_id":       "hello",
+		"contenttype_id": "post",
+		"locale":         "en-US",
+		"published":      false,
+		"archived":       false,
+		"field": []interface{}{
+			map[string]interface{}{"id": "title", "locale": "en-US", "content": "Hello"},
+			map[string]interface{}{"id": "summary", "locale": "en-US", "content": "Greeting"},
+		},
+	}
+	meta := &providerMeta{client: client, environments: newEnvironmentCache()}
+
+	// The content type is created in the same apply, so the fields are checked before the entry is written.
+	if _, err := r.SimpleDiff(ctx, nil, terraform.NewResourceConfigRaw(config), meta); err != nil {
+		t.Fatalf("plan of a content type which does not exist should not check the fields, got %v", err)
+	}
+
+	ct := &ContentType{
+		Sys:    &contentful.Sys{ID: "post"},
+		Name:   "Post",
+		Fields: []*ContentTypeField{{Field: contentful.Field{ID: "title", Name: "Title", Type: "Symbol"}}},
+	}
+	if err := upsertAndActivate(ctx, contentTypes, env, ct); err != nil {
+		t.Fatal(err)
+	}
+	_, err := r.SimpleDiff(ctx, nil, terraform.NewResourceConfigRaw(config), meta)
+	if err == nil || !strings.Contains(err.Error(), `field.1.id: content type post has no field "summary"`) {
+		t.Fatalf("plan error = %v, should report the missing field", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	diags := resourceCreateEntry(ctx, d, env, client.Entries, newEntryFieldsChecker(client))
+	if len(diags) != 1 || formatAttributePath(diags[0].AttributePath) != "field.1.id" {
+		t.Fatalf("resourceCreateEntry() diags = %v, should point at the missing field", diags)
+	}
+
+	ct, err = contentTypes.Get(ctx, env, "post")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ct.Fields = append(ct.Fields, &ContentTypeField{Field: contentful.Field{ID: "summary", Name: "Summary", Type: "Text"}})
+	if err := upsertAndActivate(ctx, contentTypes, env, ct); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.SimpleDiff(ctx, nil, terraform.NewResourceConfigRaw(config), meta); err != nil {
+		t.Fatalf("plan error = %v", err)
+	}
+	if diags := resourceCreateEntry(ctx, d, env, client.Entries, newEntryFieldsChecker(client)); diags.HasError() {
+		t.Fatalf("resourceCreateEntry() diags = %v", diags)
+	}
+}
+
+func TestResourceEntry_roundTrip(t *testing.T) {
+	ctx := context.Background()
+	client, env := newFakeCMAEnvironment(t)
+	entities := &environmentEntitiesService{c: newCMAClient(client)}
+
+	if _, err := entities.Post(ctx, env, "locales", EntityDocument{"name": "German", "code": "de", "fallbackCode": "en-US"}); err != nil {
+		t.Fatal(err)
+	}
+	ct := &ContentType{
+		Sys:  &contentful.Sys{ID: "post"},
+		Name: "Post",
+		Fields: []*ContentTypeField{
+			{Field: contentful.Field{ID: "title", Name: "Title", Type: "Symbol", Localized: true}},
+			{Field: contentful.Field{ID: "views", Name: "Views", Type: "Integer"}},
+			{Field: contentful.Field{ID: "tags", Name: "Tags", Type: "Array", Items: &contentful.FieldTypeArrayItem{Type: "Symbol"}}},
+		},
+	}
+	if err := upsertAndActivate(ctx, &contentTypesService{c: newCMAClient(client)}, env, ct); err != nil {
+		t.Fatal(err)
+	}
+
+	fields := []interface{}{
+		map[string]interface{}{"id": "title", "locale": "en-US", "content": "Hello"},
+		map[string]interface{}{"id": "title", "locale": "de", "content": "Hallo"},
+		map[string]interface{}{"id": "views", "locale": "en-US", "content": "10"},
+		map[string]interface{}{"id": "tags", "locale": "en-US", "content": `[ "news", "tech" ]`},
+	}
+	d := schema.TestResourceDataRaw(t, resourceContentfulEntry().Schema, map[string]interface{}{
+		"space_id":       "space",
+		"env_id":         "master",
+		"entry_id":       "hello",
+		"contenttype_id": "post",
+		"locale":         "en-US",
+		"published":      false,
+		"archived":       false,
+		"field":          fields,
+	})
+	if diags := resourceCreateEntry(ctx, d, env, client.Entries, newEntryFieldsChecker(client)); diags.HasError() {
+		t.Fatalf("resourceCreateEntry() diags = %v", diags)
+	}
+
+	entry, err := client.Entries.Get(ctx, env, "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectFields := map[string]interface{}{
+		"title": map[string]interface{}{"en-US": "Hello", "de": "Hallo"},
+		"views": map[string]interface{}{"en-US": float64(10)},
+		"tags":  map[string]interface{}{"en-US": []interface{}{"news", "tech"}},
+	}
+	if diff := cmp.Diff(expectFields, entry.Fields); diff != "" {
+		t.Errorf("fields of the entry diff (-expect, +got)\n%s", diff)
+	}
+
+	if diags := resourceReadEntry(ctx, d, env, client.Entries, newEntryFieldsChecker(client)); diags.HasError() {
+		t.Fatalf("resourceReadEntry() diags = %v", diags)
+	}
+	if diff := cmp.Diff(fields, d.Get("field")); diff != "" {
+		t.Errorf("field after read diff (-expect, +got)\n%s", diff)
+	}
+}
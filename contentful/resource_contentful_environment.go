@@ -2,44 +2,63 @@ package contentful
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	contentful "github.com/kitagry/contentful-go"
 )
 
+// environmentPollInterval is the interval to check whether a new environment is ready.
+var environmentPollInterval = 2 * time.Second
+
 func resourceContentfulEnvironment() *schema.Resource {
 	return &schema.Resource{
+		Description:   "Manages an environment of a space. A new environment is a copy of the master environment",
 		CreateContext: wrapEnvironment(resourceCreateEnvironment),
 		ReadContext:   wrapEnvironment(resourceReadEnvironment),
 		UpdateContext: wrapEnvironment(resourceUpdateEnvironment),
 		DeleteContext: wrapEnvironment(resourceDeleteEnvironment),
+		CustomizeDiff: setProviderDefaults,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"version": {
-				Type:     schema.TypeInt,
-				Computed: true,
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The current version of the environment, which increases on every change",
 			},
 			"space_id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the space. Defaults to `space_id` of the provider",
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the environment, which is also its ID",
 			},
 		},
 	}
 }
 
-func wrapEnvironment(f func(ctx context.Context, d *schema.ResourceData, apiKey ContentfulEnvironmentClient) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+func wrapEnvironment(f func(ctx context.Context, d *schema.ResourceData, apiKey ContentfulEnvironmentClient, statuses ContentfulEnvironmentStatusClient) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-		client := m.(*contentful.Client)
-		return f(ctx, d, client.Environments)
+		meta := m.(*providerMeta)
+		client := &cachingEnvironmentClient{ContentfulEnvironmentClient: meta.client.Environments, cache: meta.environments}
+		return f(ctx, d, client, &environmentsService{c: newCMAClient(meta.client)})
 	}
 }
 
-func resourceCreateEnvironment(ctx context.Context, d *schema.ResourceData, client ContentfulEnvironmentClient) (diags diag.Diagnostics) {
+func resourceCreateEnvironment(ctx context.Context, d *schema.ResourceData, client ContentfulEnvironmentClient, statuses ContentfulEnvironmentStatusClient) (diags diag.Diagnostics) {
 	environment := &contentful.Environment{
 		Name: d.Get("name").(string),
 	}
@@ -57,10 +76,35 @@ func resourceCreateEnvironment(ctx context.Context, d *schema.ResourceData, clie
 
 	d.SetId(environment.Name)
 
+	if err := waitForEnvironmentReady(ctx, statuses, d.Get("space_id").(string), d.Id()); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
 	return nil
 }
 
-func resourceUpdateEnvironment(ctx context.Context, d *schema.ResourceData, client ContentfulEnvironmentClient) (diags diag.Diagnostics) {
+// waitForEnvironmentReady waits until a new environment has been copied from its source environment.
+func waitForEnvironmentReady(ctx context.Context, client ContentfulEnvironmentStatusClient, spaceID, environmentID string) error {
+	var status string
+	err := pollUntil(ctx, environmentPollInterval, func() (bool, error) {
+		var err error
+		status, err = client.GetStatus(ctx, spaceID, environmentID)
+		if err != nil {
+			return false, err
+		}
+		if status == environmentStatusFailed {
+			return false, fmt.Errorf("environment %s failed to be created", environmentID)
+		}
+		return status == environmentStatusReady, nil
+	})
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("timed out waiting for environment %s to be ready, its status is %q: %w", environmentID, status, err)
+	}
+	return err
+}
+
+func resourceUpdateEnvironment(ctx context.Context, d *schema.ResourceData, client ContentfulEnvironmentClient, statuses ContentfulEnvironmentStatusClient) (diags diag.Diagnostics) {
 	spaceID := d.Get("space_id").(string)
 	environmentID := d.Id()
 	defer func() {
@@ -93,7 +137,7 @@ func resourceUpdateEnvironment(ctx context.Context, d *schema.ResourceData, clie
 	return nil
 }
 
-func resourceReadEnvironment(ctx context.Context, d *schema.ResourceData, client ContentfulEnvironmentClient) (diags diag.Diagnostics) {
+func resourceReadEnvironment(ctx context.Context, d *schema.ResourceData, client ContentfulEnvironmentClient, statuses ContentfulEnvironmentStatusClient) (diags diag.Diagnostics) {
 	spaceID := d.Get("space_id").(string)
 	environmentID := d.Id()
 
@@ -115,7 +159,7 @@ func resourceReadEnvironment(ctx context.Context, d *schema.ResourceData, client
 	return
 }
 
-func resourceDeleteEnvironment(ctx context.Context, d *schema.ResourceData, client ContentfulEnvironmentClient) (diags diag.Diagnostics) {
+func resourceDeleteEnvironment(ctx context.Context, d *schema.ResourceData, client ContentfulEnvironmentClient, statuses ContentfulEnvironmentStatusClient) (diags diag.Diagnostics) {
 	spaceID := d.Get("space_id").(string)
 	environmentID := d.Id()
 
@@ -0,0 +1,698 @@
+package contentful
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+// The kinds of the entities of an export, in the order they are reconciled.
+// Entities are reconciled after the entities they depend on, such as entries after their content types.
+const (
+	exportKindLocale          = "Locale"
+	exportKindContentType     = "ContentType"
+	exportKindEditorInterface = "EditorInterface"
+	exportKindAsset           = "Asset"
+	exportKindEntry           = "Entry"
+)
+
+func resourceContentfulEnvironmentContent() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Reconciles the content of an environment to a JSON file written by `contentful space export`: its locales, content types, editor interfaces, assets and entries. Other entities of the environment are left as they are",
+		CreateContext: wrapEnvironmentContent(resourceCreateEnvironmentContent),
+		ReadContext:   wrapEnvironmentContent(resourceReadEnvironmentContent),
+		UpdateContext: wrapEnvironmentContent(resourceUpdateEnvironmentContent),
+		DeleteContext: wrapEnvironmentContent(resourceDeleteEnvironmentContent),
+		CustomizeDiff: customdiff.All(setProviderDefaults, planEnvironmentContent),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"space_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the space. Defaults to `space_id` of the provider",
+			},
+			"env_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the environment. Defaults to `environment_id` of the provider",
+			},
+			"file": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Path of the JSON file written by `contentful space export`. Its `locales`, `contentTypes`, `editorInterfaces`, `assets` and `entries` are reconciled. Entries and assets are published when they are published in the export",
+			},
+			"content_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA-256 hash of the entities of the export as they are in the environment. It differs from the hash of the file when the entities are changed in the environment, which plans to reconcile them again",
+			},
+			"entities": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The reconciled entities as `<type>:<id>`, such as `Entry:hello`. Locales are identified by their code. Entities which are removed from the export are deleted from the environment",
+			},
+		},
+	}
+}
+
+// exportEntity is an entity of a contentful-export file.
+type exportEntity struct {
+	kind string
+	// id is the code of a locale, the ID of the content type of an editor interface, and sys.id otherwise.
+	id  string
+	doc EntityDocument
+}
+
+func (e *exportEntity) key() string {
+	return e.kind + ":" + e.id
+}
+
+// readEnvironmentExport reads the entities of a contentful-export file, in the order they are reconciled.
+func readEnvironmentExport(path string) ([]*exportEntity, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var export struct {
+		Locales          []EntityDocument `json:"locales"`
+		ContentTypes     []EntityDocument `json:"contentTypes"`
+		EditorInterfaces []EntityDocument `json:"editorInterfaces"`
+		Assets           []EntityDocument `json:"assets"`
+		Entries          []EntityDocument `json:"entries"`
+	}
+	if err := json.Unmarshal(b, &export); err != nil {
+		return nil, fmt.Errorf("%s is not a contentful-export file: %w", path, err)
+	}
+
+	var entities []*exportEntity
+	seen := map[string]bool{}
+	add := func(kind string, docs []EntityDocument, id func(EntityDocument) string) error {
+		for i, doc := range docs {
+			entity := &exportEntity{kind: kind, id: id(doc), doc: doc}
+			if entity.id == "" {
+				return fmt.Errorf("%s[%d] of %s has no ID", kind, i, path)
+			}
+			if seen[entity.key()] {
+				return fmt.Errorf("%s %s appears twice in %s", kind, entity.id, path)
+			}
+			seen[entity.key()] = true
+			entities = append(entities, entity)
+		}
+		return nil
+	}
+
+	sysID := func(doc EntityDocument) string { return doc.ID() }
+	if err := add(exportKindLocale, export.Locales, func(doc EntityDocument) string { return stringValue(doc["code"]) }); err != nil {
+		return nil, err
+	}
+	if err := add(exportKindContentType, export.ContentTypes, sysID); err != nil {
+		return nil, err
+	}
+	if err := add(exportKindEditorInterface, export.EditorInterfaces, func(doc EntityDocument) string { return linkID(doc.Sys()["contentType"]) }); err != nil {
+		return nil, err
+	}
+	if err := add(exportKindAsset, export.Assets, sysID); err != nil {
+		return nil, err
+	}
+	if err := add(exportKindEntry, export.Entries, sysID); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+// entityState encodes the properties of doc which the export entity defines, so that the states of the entity in
+// the export and in the environment are equal when the environment is reconciled. exported tells whether doc is
+// the entity of the export or the entity of the environment.
+func entityState(entity *exportEntity, doc EntityDocument, exported bool) (string, error) {
+	if doc == nil {
+		return "", nil
+	}
+
+	var state map[string]interface{}
+	switch entity.kind {
+	case exportKindLocale:
+		state = pick(doc, "name", "code", "fallbackCode", "optional", "contentDeliveryApi", "contentManagementApi")
+	case exportKindContentType:
+		b, err := json.Marshal(map[string]interface{}{"fields": doc["fields"]})
+		if err != nil {
+			return "", err
+		}
+		fields, err := normalizeContentTypeDefinition(string(b))
+		if err != nil {
+			return "", err
+		}
+		state = map[string]interface{}{
+			"name":         stringValue(doc["name"]),
+			"description":  stringValue(doc["description"]),
+			"displayField": stringValue(doc["displayField"]),
+			"fields":       fields,
+			"active":       exported || isEntityPublished(doc, false),
+		}
+	case exportKindEditorInterface:
+		var keys []string
+		for k := range entity.doc {
+			if k != "sys" {
+				keys = append(keys, k)
+			}
+		}
+		state = pick(doc, keys...)
+	case exportKindAsset:
+		fields, _ := doc["fields"].(map[string]interface{})
+		files := map[string]interface{}{}
+		if file, ok := fields["file"].(map[string]interface{}); ok {
+			for locale, v := range file {
+				f, ok := v.(map[string]interface{})
+				if !ok {
+					return "", fmt.Errorf("the file of locale %s is not an object", locale)
+				}
+				files[locale] = pick(f, "fileName", "contentType")
+			}
+		}
+		state = map[string]interface{}{
+			"title":       fields["title"],
+			"description": fields["description"],
+			"file":        files,
+			"published":   isEntityPublished(doc, exported),
+		}
+	case exportKindEntry:
+		state = map[string]interface{}{
+			"contentType": linkID(doc.Sys()["contentType"]),
+			"fields":      doc["fields"],
+			"published":   isEntityPublished(doc, exported),
+		}
+	}
+
+	b, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// isEntityPublished returns whether doc is published. An entity of the export is published when it has been
+// published at all, while an entity of the environment is published when it has no changes after that.
+func isEntityPublished(doc EntityDocument, exported bool) bool {
+	if doc.PublishedVersion() == 0 {
+		return false
+	}
+	return exported || doc.Version() == doc.PublishedVersion()+1
+}
+
+func pick(doc map[string]interface{}, keys ...string) map[string]interface{} {
+	result := make(map[string]interface{}, len(keys))
+	for _, k := range keys {
+		result[k] = doc[k]
+	}
+	return result
+}
+
+func stringValue(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+// environmentContentHash hashes the states of the entities, which are keyed by exportEntity.key.
+func environmentContentHash(entities []*exportEntity, states map[string]string) string {
+	h := sha256.New()
+	for _, entity := range entities {
+		fmt.Fprintf(h, "%s\t%s\n", entity.key(), states[entity.key()])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func exportEntityKeys(entities []*exportEntity) []string {
+	keys := make([]string, 0, len(entities))
+	for _, entity := range entities {
+		keys = append(keys, entity.key())
+	}
+	return keys
+}
+
+// desiredEnvironmentContent returns the hash of the entities as they are in the export.
+func desiredEnvironmentContent(entities []*exportEntity) (string, error) {
+	states := make(map[string]string, len(entities))
+	for _, entity := range entities {
+		state, err := entityState(entity, entity.doc, true)
+		if err != nil {
+			return "", fmt.Errorf("%s %s: %w", entity.kind, entity.id, err)
+		}
+		states[entity.key()] = state
+	}
+	return environmentContentHash(entities, states), nil
+}
+
+// planEnvironmentContent plans content_hash and entities from the file, so that a changed file, or an environment
+// which no longer matches the file, is reconciled.
+func planEnvironmentContent(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("file") {
+		if err := d.SetNewComputed("content_hash"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("entities")
+	}
+
+	entities, err := readEnvironmentExport(d.Get("file").(string))
+	if err != nil {
+		return err
+	}
+	hash, err := desiredEnvironmentContent(entities)
+	if err != nil {
+		return err
+	}
+
+	if d.Get("content_hash").(string) != hash {
+		if err := d.SetNew("content_hash", hash); err != nil {
+			return err
+		}
+	}
+	keys := exportEntityKeys(entities)
+	if strings.Join(toStrings(d.Get("entities").([]interface{})), "\n") != strings.Join(keys, "\n") {
+		if err := d.SetNew("entities", keys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func wrapEnvironmentContent(f func(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEnvironmentEntityClient) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+		meta := m.(*providerMeta)
+		env, err := meta.environments.Get(ctx, meta.client.Environments, d.Get("space_id").(string), d.Get("env_id").(string))
+		if err != nil {
+			diags = append(diags, contentfulErrorToDiagnostic(err)...)
+			return
+		}
+		return f(ctx, d, env, &environmentEntitiesService{c: newCMAClient(meta.client)})
+	}
+}
+
+func resourceCreateEnvironmentContent(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEnvironmentEntityClient) (diags diag.Diagnostics) {
+	d.SetId(fmt.Sprintf("%s/%s", d.Get("space_id"), d.Get("env_id")))
+	return reconcileEnvironmentContent(ctx, d, env, client, nil)
+}
+
+func resourceUpdateEnvironmentContent(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEnvironmentEntityClient) (diags diag.Diagnostics) {
+	old, _ := d.GetChange("entities")
+	return reconcileEnvironmentContent(ctx, d, env, client, toStrings(old.([]interface{})))
+}
+
+// reconcileEnvironmentContent makes the environment match the file, and deletes the entities of previous which
+// are no longer in the file. An entity which fails is reported, and the other entities are reconciled anyway.
+func reconcileEnvironmentContent(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEnvironmentEntityClient, previous []string) (diags diag.Diagnostics) {
+	defer func() {
+		if diags.HasError() {
+			d.Partial(true)
+		}
+	}()
+
+	entities, err := readEnvironmentExport(d.Get("file").(string))
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+	c := &environmentContent{client: client, env: env}
+	observed, err := c.observe(ctx, entities)
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	keys := map[string]bool{}
+	for _, entity := range entities {
+		keys[entity.key()] = true
+		desired, err := entityState(entity, entity.doc, true)
+		if err != nil {
+			diags = append(diags, entityErrorToDiagnostic(entity.kind, entity.id, err)...)
+			continue
+		}
+		if state, _ := entityState(entity, observed[entity.key()], false); state == desired {
+			continue
+		}
+		if err := c.reconcile(ctx, entity, observed[entity.key()]); err != nil {
+			diags = append(diags, entityErrorToDiagnostic(entity.kind, entity.id, err)...)
+		}
+	}
+
+	var stale []string
+	for _, key := range previous {
+		if !keys[key] {
+			stale = append(stale, key)
+		}
+	}
+	diags = append(diags, c.deleteAll(ctx, stale)...)
+
+	if err := d.Set("entities", exportEntityKeys(entities)); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+	if diags.HasError() {
+		return
+	}
+	return resourceReadEnvironmentContent(ctx, d, env, client)
+}
+
+func resourceReadEnvironmentContent(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEnvironmentEntityClient) (diags diag.Diagnostics) {
+	entities, err := readEnvironmentExport(d.Get("file").(string))
+	if err != nil {
+		// Without the file it is unknown which properties to compare, so the state is kept as it is.
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "The content of the environment is not refreshed",
+			Detail:   err.Error(),
+		}}
+	}
+
+	c := &environmentContent{client: client, env: env}
+	observed, err := c.observe(ctx, entities)
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	states := make(map[string]string, len(entities))
+	for _, entity := range entities {
+		state, err := entityState(entity, observed[entity.key()], false)
+		if err != nil {
+			diags = append(diags, entityErrorToDiagnostic(entity.kind, entity.id, err)...)
+			return
+		}
+		states[entity.key()] = state
+	}
+	if err := d.Set("content_hash", environmentContentHash(entities, states)); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+	}
+	return
+}
+
+func resourceDeleteEnvironmentContent(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, client ContentfulEnvironmentEntityClient) (diags diag.Diagnostics) {
+	c := &environmentContent{client: client, env: env}
+	return c.deleteAll(ctx, toStrings(d.Get("entities").([]interface{})))
+}
+
+// environmentContent reads and writes the entities of an export in an environment.
+type environmentContent struct {
+	client ContentfulEnvironmentEntityClient
+	env    *contentful.Environment
+}
+
+// observe returns the entities as they are in the environment, keyed by exportEntity.key. Missing entities are
+// left out.
+func (c *environmentContent) observe(ctx context.Context, entities []*exportEntity) (map[string]EntityDocument, error) {
+	observed := map[string]EntityDocument{}
+	var locales map[string]EntityDocument
+	for _, entity := range entities {
+		var doc EntityDocument
+		var err error
+		switch entity.kind {
+		case exportKindLocale:
+			if locales == nil {
+				if locales, err = c.locales(ctx); err != nil {
+					return nil, err
+				}
+			}
+			doc = locales[entity.id]
+		default:
+			doc, err = c.client.Get(ctx, c.env, entityPath(entity.kind, entity.id))
+			if _, ok := err.(contentful.NotFoundError); ok {
+				continue
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s %s: %w", entity.kind, entity.id, err)
+		}
+		if doc != nil {
+			observed[entity.key()] = doc
+		}
+	}
+	return observed, nil
+}
+
+// locales returns the locales of the environment by code.
+func (c *environmentContent) locales(ctx context.Context) (map[string]EntityDocument, error) {
+	docs, err := c.client.List(ctx, c.env, "locales")
+	if err != nil {
+		return nil, err
+	}
+	locales := make(map[string]EntityDocument, len(docs))
+	for _, doc := range docs {
+		locales[stringValue(doc["code"])] = doc
+	}
+	return locales, nil
+}
+
+func entityPath(kind, id string) string {
+	switch kind {
+	case exportKindLocale:
+		return "locales/" + id
+	case exportKindContentType:
+		return "content_types/" + id
+	case exportKindEditorInterface:
+		return "content_types/" + id + "/editor_interface"
+	case exportKindAsset:
+		return "assets/" + id
+	}
+	return "entries/" + id
+}
+
+// reconcile writes the entity of the export over current, which is nil when the entity does not exist yet.
+func (c *environmentContent) reconcile(ctx context.Context, entity *exportEntity, current EntityDocument) error {
+	body := EntityDocument{}
+	for k, v := range entity.doc {
+		if k != "sys" {
+			body[k] = v
+		}
+	}
+	version := current.Version()
+
+	switch entity.kind {
+	case exportKindLocale:
+		body = EntityDocument(pick(body, "name", "code", "fallbackCode", "optional", "contentDeliveryApi", "contentManagementApi"))
+		if current == nil {
+			_, err := c.client.Post(ctx, c.env, "locales", body)
+			return err
+		}
+		// The default locale stays the default.
+		if current["default"] == true {
+			body["default"] = true
+		}
+		_, err := c.client.Put(ctx, c.env, entityPath(entity.kind, current.ID()), version, body, "")
+		return err
+
+	case exportKindContentType:
+		doc, err := c.client.Put(ctx, c.env, entityPath(entity.kind, entity.id), version, body, "")
+		if err != nil {
+			return err
+		}
+		_, err = c.client.Put(ctx, c.env, entityPath(entity.kind, entity.id)+"/published", doc.Version(), nil, "")
+		return err
+
+	case exportKindEditorInterface:
+		// The editor interface is created with its content type, which may have been created just now.
+		current, err := c.client.Get(ctx, c.env, entityPath(entity.kind, entity.id))
+		if err != nil {
+			return err
+		}
+		_, err = c.client.Put(ctx, c.env, entityPath(entity.kind, entity.id), current.Version(), body, "")
+		return err
+
+	case exportKindAsset:
+		return c.reconcileAsset(ctx, entity, body, current)
+	}
+
+	doc, err := c.client.Put(ctx, c.env, entityPath(entity.kind, entity.id), version, body, linkID(entity.doc.Sys()["contentType"]))
+	if err != nil {
+		return err
+	}
+	return c.setPublished(ctx, entity, doc)
+}
+
+// reconcileAsset writes the asset and processes the files which changed. The files of the export are downloaded
+// from the URLs they have in the export.
+func (c *environmentContent) reconcileAsset(ctx context.Context, entity *exportEntity, body, current EntityDocument) error {
+	fields, _ := body["fields"].(map[string]interface{})
+	files, _ := fields["file"].(map[string]interface{})
+	currentFields, _ := current["fields"].(map[string]interface{})
+	currentFiles, _ := currentFields["file"].(map[string]interface{})
+
+	newFields := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		newFields[k] = v
+	}
+	newFiles := make(map[string]interface{}, len(files))
+	var process []string
+	for locale, v := range files {
+		file, _ := v.(map[string]interface{})
+		currentFile, _ := currentFiles[locale].(map[string]interface{})
+		if currentFile != nil && stringValue(currentFile["url"]) != "" && fmt.Sprint(pick(currentFile, "fileName", "contentType")) == fmt.Sprint(pick(file, "fileName", "contentType")) {
+			newFiles[locale] = currentFile
+			continue
+		}
+
+		upload := stringValue(file["upload"])
+		if url := stringValue(file["url"]); url != "" {
+			upload = url
+			if strings.HasPrefix(upload, "//") {
+				upload = "https:" + upload
+			}
+		}
+		newFiles[locale] = map[string]interface{}{
+			"fileName":    file["fileName"],
+			"contentType": file["contentType"],
+			"upload":      upload,
+		}
+		process = append(process, locale)
+	}
+	if len(files) > 0 {
+		newFields["file"] = newFiles
+	}
+	body["fields"] = newFields
+
+	path := entityPath(entity.kind, entity.id)
+	doc, err := c.client.Put(ctx, c.env, path, current.Version(), body, "")
+	if err != nil {
+		return err
+	}
+	if len(process) > 0 {
+		for _, locale := range process {
+			if _, err := c.client.Put(ctx, c.env, path+"/files/"+locale+"/process", doc.Version(), nil, ""); err != nil {
+				return err
+			}
+		}
+		err = pollUntil(ctx, assetProcessPollInterval, func() (bool, error) {
+			doc, err = c.client.Get(ctx, c.env, path)
+			if err != nil {
+				return false, err
+			}
+			fields, _ := doc["fields"].(map[string]interface{})
+			files, _ := fields["file"].(map[string]interface{})
+			for _, v := range files {
+				if file, _ := v.(map[string]interface{}); stringValue(file["url"]) == "" {
+					return false, nil
+				}
+			}
+			return true, nil
+		})
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("timed out waiting for the files to be processed: %w", err)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return c.setPublished(ctx, entity, doc)
+}
+
+// setPublished publishes doc, the entry or asset just written, when the entity is published in the export,
+// and unpublishes it otherwise.
+func (c *environmentContent) setPublished(ctx context.Context, entity *exportEntity, doc EntityDocument) error {
+	path := entityPath(entity.kind, entity.id) + "/published"
+	if entity.doc.PublishedVersion() != 0 {
+		_, err := c.client.Put(ctx, c.env, path, doc.Version(), nil, "")
+		return err
+	}
+	if doc.PublishedVersion() != 0 {
+		_, err := c.client.Delete(ctx, c.env, path, doc.Version())
+		return err
+	}
+	return nil
+}
+
+// deleteAll deletes the entities of keys, dependent entities first. Editor interfaces are deleted with their
+// content types, and the default locale cannot be deleted, so they are left as they are.
+func (c *environmentContent) deleteAll(ctx context.Context, keys []string) (diags diag.Diagnostics) {
+	var locales map[string]EntityDocument
+	for _, kind := range []string{exportKindEntry, exportKindAsset, exportKindContentType, exportKindLocale} {
+		for _, key := range keys {
+			id := strings.TrimPrefix(key, kind+":")
+			if id == key {
+				continue
+			}
+
+			var err error
+			if kind == exportKindLocale {
+				if locales == nil {
+					if locales, err = c.locales(ctx); err != nil {
+						diags = append(diags, contentfulErrorToDiagnostic(err)...)
+						return
+					}
+				}
+				if locale, ok := locales[id]; ok && locale["default"] != true {
+					_, err = c.client.Delete(ctx, c.env, entityPath(kind, locale.ID()), locale.Version())
+				}
+			} else {
+				err = c.delete(ctx, kind, id)
+			}
+			if err != nil {
+				diags = append(diags, entityErrorToDiagnostic(kind, id, err)...)
+			}
+		}
+	}
+	return
+}
+
+// delete unpublishes and deletes an entry, an asset or a content type. Entities which do not exist are ignored.
+func (c *environmentContent) delete(ctx context.Context, kind, id string) error {
+	path := entityPath(kind, id)
+	doc, err := c.client.Get(ctx, c.env, path)
+	if _, ok := err.(contentful.NotFoundError); ok {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if doc.PublishedVersion() != 0 {
+		if doc, err = c.client.Delete(ctx, c.env, path+"/published", doc.Version()); err != nil {
+			return err
+		}
+	}
+	_, err = c.client.Delete(ctx, c.env, path, doc.Version())
+	return err
+}
+
+// entityErrorToDiagnostic reports the error of an entity. Paths of the API errors point into the entity rather
+// than into the configuration, so they are added to the detail.
+func entityErrorToDiagnostic(kind, id string, err error) diag.Diagnostics {
+	diags := contentfulErrorToDiagnostic(err)
+	for i := range diags {
+		diags[i].Summary = fmt.Sprintf("%s %s: %s", kind, id, diags[i].Summary)
+		if len(diags[i].AttributePath) > 0 {
+			var elems []string
+			for _, step := range diags[i].AttributePath {
+				switch s := step.(type) {
+				case cty.GetAttrStep:
+					elems = append(elems, s.Name)
+				case cty.IndexStep:
+					elems = append(elems, s.Key.AsBigFloat().String())
+				}
+			}
+			diags[i].Detail = fmt.Sprintf("%s: %s", strings.Join(elems, "."), diags[i].Detail)
+			diags[i].AttributePath = nil
+		}
+	}
+	return diags
+}
@@ -0,0 +1,202 @@
+package contentful
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+const testEnvironmentExport = `{
+  "locales": [
+    {"sys": {"id": "exported-en"}, "name": "English (United States)", "code": "en-US", "fallbackCode": null, "default": true, "optional": false, "contentDeliveryApi": true, "contentManagementApi": true},
+    {"sys": {"id": "exported-de"}, "name": "German", "code": "de", "fallbackCode": "en-US", "default": false, "optional": true, "contentDeliveryApi": true, "contentManagementApi": true}
+  ],
+  "contentTypes": [
+    {
+      "sys": {"id": "post", "version": 3, "publishedVersion": 2},
+      "name": "Post",
+      "description": "",
+      "displayField": "title",
+      "fields": [
+        {"id": "title", "name": "Title", "type": "Symbol", "localized": true, "required": true, "validations": [], "disabled": false, "omitted": false}
+      ]
+    }
+  ],
+  "editorInterfaces": [
+    {
+      "sys": {"id": "default", "contentType": {"sys": {"id": "post", "type": "Link", "linkType": "ContentType"}}},
+      "controls": [{"fieldId": "title", "widgetId": "singleLine", "widgetNamespace": "builtin"}]
+    }
+  ]%s
+}`
+
+const testEnvironmentExportEntries = `,
+  "assets": [
+    {
+      "sys": {"id": "logo", "version": 4, "publishedVersion": 3},
+      "fields": {
+        "title": {"en-US": "Logo"},
+        "file": {"en-US": {"url": "//images.ctfassets.net/space/logo/logo.png", "fileName": "logo.png", "contentType": "image/png"}}
+      }
+    }
+  ],
+  "entries": [
+    {
+      "sys": {"id": "hello", "version": 2, "publishedVersion": 1, "contentType": {"sys": {"id": "post", "type": "Link", "linkType": "ContentType"}}},
+      "fields": {"title": {"en-US": "Hello", "de": "Hallo"}}
+    },
+    {
+      "sys": {"id": "draft", "version": 1, "contentType": {"sys": {"id": "post", "type": "Link", "linkType": "ContentType"}}},
+      "fields": {"title": {"en-US": "Draft"}}
+    }
+  ]`
+
+func TestResourceEnvironmentContent(t *testing.T) {
+	ctx := context.Background()
+	client, env := newFakeCMAEnvironment(t)
+	entities := &environmentEntitiesService{c: newCMAClient(client)}
+
+	file := filepath.Join(t.TempDir(), "export.json")
+	writeExport := func(entries string) {
+		if err := os.WriteFile(file, []byte(fmt.Sprintf(testEnvironmentExport, entries)), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeExport(testEnvironmentExportEntries)
+
+	r := resourceContentfulEnvironmentContent()
+	config := map[string]interface{}{"space_id": "space", "env_id": "master", "file": file}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := resourceCreateEnvironmentContent(ctx, d, env, entities); diags.HasError() {
+		t.Fatalf("resourceCreateEnvironmentContent() diags = %v", diags)
+	}
+
+	desiredHash := func() string {
+		exported, err := readEnvironmentExport(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hash, err := desiredEnvironmentContent(exported)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hash
+	}
+	if got, expect := d.Get("content_hash").(string), desiredHash(); got != expect {
+		t.Errorf("content_hash after create = %s, expect %s", got, expect)
+	}
+	expectEntities := []string{"Locale:en-US", "Locale:de", "ContentType:post", "EditorInterface:post", "Asset:logo", "Entry:hello", "Entry:draft"}
+	if diff := cmp.Diff(expectEntities, toStrings(d.Get("entities").([]interface{}))); diff != "" {
+		t.Errorf("entities diff (-expect, +got)\n%s", diff)
+	}
+
+	for path, expect := range map[string]string{
+		"entries/hello": entityStatusPublished,
+		"entries/draft": entityStatusDraft,
+		"assets/logo":   entityStatusPublished,
+	} {
+		doc, err := entities.Get(ctx, env, path)
+		if err != nil {
+			t.Fatalf("%s was not created: %v", path, err)
+		}
+		if got := entityStatus(&contentful.Sys{Version: doc.Version(), PublishedVersion: doc.PublishedVersion()}); got != expect {
+			t.Errorf("status of %s = %s, expect %s", path, got, expect)
+		}
+	}
+
+	// A change in the environment shows up as a different hash, and the next update reverts it.
+	hello, _ := entities.Get(ctx, env, "entries/hello")
+	if _, err := entities.Put(ctx, env, "entries/hello", hello.Version(), EntityDocument{"fields": map[string]interface{}{"title": map[string]interface{}{"en-US": "Changed"}}}, ""); err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceReadEnvironmentContent(ctx, d, env, entities); diags.HasError() {
+		t.Fatalf("resourceReadEnvironmentContent() diags = %v", diags)
+	}
+	if d.Get("content_hash").(string) == desiredHash() {
+		t.Error("content_hash did not change with the entry")
+	}
+
+	update := func() {
+		t.Helper()
+		state := d.State()
+		diff, err := r.SimpleDiff(ctx, state, terraform.NewResourceConfigRaw(config), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, err = schema.InternalMap(r.Schema).Data(state, diff)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diags := resourceUpdateEnvironmentContent(ctx, d, env, entities); diags.HasError() {
+			t.Fatalf("resourceUpdateEnvironmentContent() diags = %v", diags)
+		}
+	}
+	update()
+	if got, expect := d.Get("content_hash").(string), desiredHash(); got != expect {
+		t.Errorf("content_hash after update = %s, expect %s", got, expect)
+	}
+
+	// Entities which are removed from the file are deleted.
+	writeExport("")
+	update()
+	if _, err := entities.Get(ctx, env, "entries/hello"); err == nil {
+		t.Error("entries/hello was not deleted")
+	}
+	if _, err := entities.Get(ctx, env, "assets/logo"); err == nil {
+		t.Error("assets/logo was not deleted")
+	}
+
+	if diags := resourceDeleteEnvironmentContent(ctx, d, env, entities); diags.HasError() {
+		t.Fatalf("resourceDeleteEnvironmentContent() diags = %v", diags)
+	}
+	if _, err := entities.Get(ctx, env, "content_types/post"); err == nil {
+		t.Error("content_types/post was not deleted")
+	}
+	locales, err := entities.List(ctx, env, "locales")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locales) != 1 {
+		t.Errorf("%d locales are left, expect the default locale only", len(locales))
+	}
+}
+
+func TestDesiredEnvironmentContent(t *testing.T) {
+	tests := map[string]struct {
+		doc EntityDocument
+
+		expectErr string
+	}{
+		"asset with a file should pass": {
+			doc: EntityDocument{"fields": map[string]interface{}{
+				"file": map[string]interface{}{"en-US": map[string]interface{}{"fileName": "logo.png", "contentType": "image/png"}},
+			}},
+		},
+		"asset with a null file should fail": {
+			doc: EntityDocument{"fields": map[string]interface{}{
+				"file": map[string]interface{}{"en-US": nil},
+			}},
+			expectErr: "Asset logo: the file of locale en-US is not an object",
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			_, err := desiredEnvironmentContent([]*exportEntity{{kind: exportKindAsset, id: "logo", doc: tt.doc}})
+			var got string
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.expectErr {
+				t.Errorf("desiredEnvironmentContent() error = %q, expect %q", got, tt.expectErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package contentful
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type stubEnvironmentStatusClient struct {
+	statuses []string
+}
+
+func (c *stubEnvironmentStatusClient) GetStatus(ctx context.Context, spaceID, environmentID string) (string, error) {
+	status := c.statuses[0]
+	if len(c.statuses) > 1 {
+		c.statuses = c.statuses[1:]
+	}
+	return status, nil
+}
+
+func TestWaitForEnvironmentReady(t *testing.T) {
+	defer func(interval time.Duration) { environmentPollInterval = interval }(environmentPollInterval)
+	environmentPollInterval = time.Millisecond
+
+	tests := map[string]struct {
+		statuses []string
+
+		expectErr      bool
+		expectDeadline bool
+	}{
+		"queued environment should be waited for": {
+			statuses: []string{environmentStatusQueued, environmentStatusQueued, environmentStatusReady},
+		},
+		"failed environment should fail": {
+			statuses:  []string{environmentStatusQueued, environmentStatusFailed},
+			expectErr: true,
+		},
+		"environment queued until the timeout should fail with the deadline": {
+			statuses:       []string{environmentStatusQueued},
+			expectErr:      true,
+			expectDeadline: true,
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			err := waitForEnvironmentReady(ctx, &stubEnvironmentStatusClient{statuses: tt.statuses}, "space", "staging")
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("waitForEnvironmentReady() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if errors.Is(err, context.DeadlineExceeded) != tt.expectDeadline {
+				t.Errorf("waitForEnvironmentReady() error = %v, expectDeadline %v", err, tt.expectDeadline)
+			}
+		})
+	}
+}
@@ -10,47 +10,59 @@ import (
 
 func resourceContentfulLocale() *schema.Resource {
 	return &schema.Resource{
+		Description:   "Manages a locale of a space",
 		CreateContext: wrapLocale(resourceCreateLocale),
 		ReadContext:   wrapLocale(resourceReadLocale),
 		UpdateContext: wrapLocale(resourceUpdateLocale),
 		DeleteContext: wrapLocale(resourceDeleteLocale),
+		Importer:      importSpaceEntity(),
+		CustomizeDiff: setProviderDefaults,
 
 		Schema: map[string]*schema.Schema{
 			"version": {
-				Type:     schema.TypeInt,
-				Computed: true,
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The current version of the locale, which increases on every change",
 			},
 			"space_id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the space. Defaults to `space_id` of the provider",
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the locale",
 			},
 			"code": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The locale code, such as `en-US`",
 			},
 			"fallback_code": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "en-US",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "en-US",
+				Description: "The code of the locale whose value is used when a field has no value in this locale",
 			},
 			"optional": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether fields may be empty in this locale when publishing an entry",
 			},
 			"cda": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  true,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the locale is available in the Content Delivery API",
 			},
 			"cma": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the locale is available in the Content Management API",
 			},
 		},
 	}
@@ -58,7 +70,7 @@ func resourceContentfulLocale() *schema.Resource {
 
 func wrapLocale(f func(ctx context.Context, d *schema.ResourceData, client ContentfulLocaleClient) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-		client := m.(*contentful.Client)
+		client := m.(*providerMeta).client
 		return f(ctx, d, client.Locales)
 	}
 }
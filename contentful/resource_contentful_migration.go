@@ -0,0 +1,617 @@
+package contentful
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+// Actions of the steps of a migration.
+const (
+	migrationActionCreateField    = "create_field"
+	migrationActionCopyField      = "copy_field"
+	migrationActionTransformField = "transform_field"
+	migrationActionDeleteField    = "delete_field"
+)
+
+// migrationIDPattern matches the characters Contentful allows in tag IDs, which record the steps.
+var migrationIDPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// maxTagIDLength is the longest tag ID Contentful accepts.
+const maxTagIDLength = 64
+
+func resourceContentfulMigration() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Runs an ordered list of steps against the entries and content types of an environment, each exactly once. A step is recorded as a private tag `migration.<migration_id>.<step_id>` of the environment after it succeeded, so it is not run again, even by another `contentful_migration` with the same `migration_id`. Steps added later run on the next apply, while changes to steps which already ran have no effect. Destroying the resource leaves the environment and the tags as they are",
+		CreateContext: wrapMigration(resourceMigrationCreate),
+		ReadContext:   wrapMigration(resourceMigrationRead),
+		UpdateContext: wrapMigration(resourceMigrationUpdate),
+		DeleteContext: resourceMigrationDelete,
+		CustomizeDiff: customdiff.All(setProviderDefaults, planMigration),
+		Importer:      importEnvironmentEntity("migration_id"),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"space_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the space. Defaults to `space_id` of the provider",
+			},
+			"env_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the environment. Defaults to `environment_id` of the provider",
+			},
+			"migration_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(migrationIDPattern, "must only contain letters, numbers, hyphens and underscores"),
+				Description:  "The ID of the migration, which identifies the records of its steps. It may only contain letters, numbers, hyphens and underscores",
+			},
+			"step": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The steps in the order they run. A failing step stops the migration, and the next apply continues with it",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(migrationIDPattern, "must only contain letters, numbers, hyphens and underscores"),
+							Description:  "The ID of the step, which is unique within the migration. It may only contain letters, numbers, hyphens and underscores",
+						},
+						"action": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								migrationActionCreateField,
+								migrationActionCopyField,
+								migrationActionTransformField,
+								migrationActionDeleteField,
+							}, false),
+							Description: "What the step does. `create_field` adds `field_id` to the content type, `copy_field` copies the values of `from_field_id` to `field_id` in every entry, `transform_field` writes the result of `template` to `field_id` in every entry, and `delete_field` deletes `field_id` from the content type",
+						},
+						"content_type_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the content type",
+						},
+						"field_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the field which is created, written or deleted",
+						},
+						"field_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name of the field created by `create_field`. Defaults to `field_id`",
+						},
+						"field_type": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "Symbol",
+							Description: "The type of the field created by `create_field`, such as `Symbol` or `Text`",
+						},
+						"localized": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the field created by `create_field` is localized",
+						},
+						"from_field_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The ID of the field `copy_field` copies the values of",
+						},
+						"template": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The Go template `transform_field` writes to a text field, such as `{{ .title | slug }}`. It is run for each locale of an entry with the values of the fields in that locale, falling back to the default locale for fields which are not localized. The functions `lower`, `upper`, `trim`, `replace` and `slug` are available. An empty result leaves the locale as it is",
+						},
+					},
+				},
+			},
+			"applied_steps": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the steps which have run, in the order of `step`",
+			},
+		},
+	}
+}
+
+// migrationStep is a step of contentful_migration.
+type migrationStep struct {
+	ID            string
+	Action        string
+	ContentTypeID string
+	FieldID       string
+	FieldName     string
+	FieldType     string
+	Localized     bool
+	FromFieldID   string
+	Template      string
+}
+
+func expandMigrationSteps(rawSteps []interface{}) []*migrationStep {
+	steps := make([]*migrationStep, 0, len(rawSteps))
+	for _, raw := range rawSteps {
+		step := raw.(map[string]interface{})
+		steps = append(steps, &migrationStep{
+			ID:            step["id"].(string),
+			Action:        step["action"].(string),
+			ContentTypeID: step["content_type_id"].(string),
+			FieldID:       step["field_id"].(string),
+			FieldName:     step["field_name"].(string),
+			FieldType:     step["field_type"].(string),
+			Localized:     step["localized"].(bool),
+			FromFieldID:   step["from_field_id"].(string),
+			Template:      step["template"].(string),
+		})
+	}
+	return steps
+}
+
+func migrationTagID(migrationID, stepID string) string {
+	return fmt.Sprintf("migration.%s.%s", migrationID, stepID)
+}
+
+// validateMigrationSteps checks the arguments each action needs, so that a migration does not stop halfway
+// because of its configuration.
+func validateMigrationSteps(migrationID string, steps []*migrationStep) error {
+	seen := map[string]bool{}
+	for i, step := range steps {
+		if seen[step.ID] {
+			return fmt.Errorf("step.%d: step ID %s is used twice", i, step.ID)
+		}
+		seen[step.ID] = true
+
+		if len(migrationTagID(migrationID, step.ID)) > maxTagIDLength {
+			return fmt.Errorf("step.%d: migration_id and step ID %s are too long to record the step, which takes a tag ID of up to %d characters", i, step.ID, maxTagIDLength)
+		}
+
+		switch step.Action {
+		case migrationActionCopyField:
+			if step.FromFieldID == "" {
+				return fmt.Errorf("step.%d: from_field_id is required by %s", i, step.Action)
+			}
+		case migrationActionTransformField:
+			if step.Template == "" {
+				return fmt.Errorf("step.%d: template is required by %s", i, step.Action)
+			}
+			if _, err := parseMigrationTemplate(step.Template); err != nil {
+				return fmt.Errorf("step.%d: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// planMigration validates the steps and plans to run the steps which have not run yet.
+func planMigration(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("step") || !d.NewValueKnown("migration_id") {
+		return nil
+	}
+
+	steps := expandMigrationSteps(d.Get("step").([]interface{}))
+	if err := validateMigrationSteps(d.Get("migration_id").(string), steps); err != nil {
+		return err
+	}
+
+	applied := map[string]bool{}
+	for _, id := range toStrings(d.Get("applied_steps").([]interface{})) {
+		applied[id] = true
+	}
+	for _, step := range steps {
+		if !applied[step.ID] {
+			return d.SetNewComputed("applied_steps")
+		}
+	}
+	return nil
+}
+
+func wrapMigration(f func(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, m *migrationClients) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+		meta := m.(*providerMeta)
+		client := meta.client
+		env, err := meta.environments.Get(ctx, client.Environments, d.Get("space_id").(string), d.Get("env_id").(string))
+		if err != nil {
+			diags = append(diags, contentfulErrorToDiagnostic(err)...)
+			return
+		}
+		return f(ctx, d, env, &migrationClients{
+			contentTypes: &contentTypesService{c: newCMAClient(client)},
+			entries:      newContentTypeEntriesClient(client),
+			entities:     &environmentEntitiesService{c: newCMAClient(client)},
+		})
+	}
+}
+
+// migrationClients are the clients the steps of a migration use. entities reads locales and records the steps as tags.
+type migrationClients struct {
+	contentTypes ContentfulContentTypeClient
+	entries      contentTypeEntriesClient
+	entities     ContentfulEnvironmentEntityClient
+}
+
+func resourceMigrationCreate(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, m *migrationClients) (diags diag.Diagnostics) {
+	d.SetId(d.Get("migration_id").(string))
+	return runMigration(ctx, d, env, m)
+}
+
+func resourceMigrationUpdate(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, m *migrationClients) (diags diag.Diagnostics) {
+	return runMigration(ctx, d, env, m)
+}
+
+// runMigration runs the steps which have not run yet, recording each of them. It stops at the first failing step,
+// because the following steps may depend on it.
+func runMigration(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, m *migrationClients) (diags diag.Diagnostics) {
+	migrationID := d.Get("migration_id").(string)
+	steps := expandMigrationSteps(d.Get("step").([]interface{}))
+	if err := validateMigrationSteps(migrationID, steps); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	applied, err := appliedMigrationSteps(ctx, m.entities, env, migrationID)
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+	done := map[string]bool{}
+	for _, id := range applied {
+		done[id] = true
+	}
+
+	for i, step := range steps {
+		if done[step.ID] {
+			continue
+		}
+		if err := runMigrationStep(ctx, env, m, step); err != nil {
+			diags = append(diags, migrationStepDiagnostic(i, step, err))
+			break
+		}
+
+		tagID := migrationTagID(migrationID, step.ID)
+		tag := EntityDocument{
+			"name": fmt.Sprintf("Migration %s: %s", migrationID, step.ID),
+			"sys":  map[string]interface{}{"id": tagID, "visibility": "private"},
+		}
+		if _, err := m.entities.Put(ctx, env, "tags/"+tagID, 0, tag, ""); err != nil {
+			diags = append(diags, migrationStepDiagnostic(i, step, fmt.Errorf("the step ran, but could not be recorded, so it runs again on the next apply: %w", err)))
+			break
+		}
+	}
+
+	return append(diags, resourceMigrationRead(ctx, d, env, m)...)
+}
+
+func migrationStepDiagnostic(i int, step *migrationStep, err error) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity:      diag.Error,
+		Summary:       fmt.Sprintf("step %s failed", step.ID),
+		Detail:        err.Error(),
+		AttributePath: cty.Path{cty.GetAttrStep{Name: "step"}, cty.IndexStep{Key: cty.NumberIntVal(int64(i))}},
+	}
+}
+
+// appliedMigrationSteps returns the IDs of the recorded steps of the migration.
+func appliedMigrationSteps(ctx context.Context, client ContentfulEnvironmentEntityClient, env *contentful.Environment, migrationID string) ([]string, error) {
+	tags, err := client.List(ctx, env, "tags")
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := migrationTagID(migrationID, "")
+	ids := []string{}
+	for _, tag := range tags {
+		if strings.HasPrefix(tag.ID(), prefix) {
+			ids = append(ids, strings.TrimPrefix(tag.ID(), prefix))
+		}
+	}
+	return ids, nil
+}
+
+func resourceMigrationRead(ctx context.Context, d *schema.ResourceData, env *contentful.Environment, m *migrationClients) (diags diag.Diagnostics) {
+	applied, err := appliedMigrationSteps(ctx, m.entities, env, d.Id())
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	if err := d.Set("migration_id", d.Id()); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+	if err := d.Set("applied_steps", orderMigrationSteps(applied, expandMigrationSteps(d.Get("step").([]interface{})))); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+	}
+	return
+}
+
+// orderMigrationSteps orders the IDs of the applied steps like steps. Steps which are no longer configured follow
+// in the order they were recorded.
+func orderMigrationSteps(applied []string, steps []*migrationStep) []string {
+	recorded := make(map[string]bool, len(applied))
+	for _, id := range applied {
+		recorded[id] = true
+	}
+
+	ordered := make([]string, 0, len(applied))
+	configured := make(map[string]bool, len(steps))
+	for _, step := range steps {
+		configured[step.ID] = true
+		if recorded[step.ID] {
+			ordered = append(ordered, step.ID)
+		}
+	}
+	for _, id := range applied {
+		if !configured[id] {
+			ordered = append(ordered, id)
+		}
+	}
+	return ordered
+}
+
+// resourceMigrationDelete only forgets the migration. The records of its steps are kept, so that a migration
+// with the same ID does not run them again.
+func resourceMigrationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+	return
+}
+
+func runMigrationStep(ctx context.Context, env *contentful.Environment, m *migrationClients, step *migrationStep) error {
+	ct, err := m.contentTypes.Get(ctx, env, step.ContentTypeID)
+	if err != nil {
+		return fmt.Errorf("failed to get content type %s: %w", step.ContentTypeID, err)
+	}
+
+	switch step.Action {
+	case migrationActionCreateField:
+		return createMigrationField(ctx, env, m, ct, step)
+	case migrationActionDeleteField:
+		return deleteMigrationField(ctx, env, m, ct, step)
+	}
+
+	field, ok := findFieldByAPIName(ct.Fields, step.FieldID)
+	if !ok {
+		return fmt.Errorf("content type %s has no field %s", step.ContentTypeID, step.FieldID)
+	}
+	var transform func(entry *contentful.Entry, locale, defaultLocale string) (interface{}, bool, error)
+	switch step.Action {
+	case migrationActionCopyField:
+		from, ok := findFieldByAPIName(ct.Fields, step.FromFieldID)
+		if !ok {
+			return fmt.Errorf("content type %s has no field %s", step.ContentTypeID, step.FromFieldID)
+		}
+		transform = func(entry *contentful.Entry, locale, defaultLocale string) (interface{}, bool, error) {
+			v, ok := localizedValue(entry, from, locale, defaultLocale)
+			return v, ok, nil
+		}
+	case migrationActionTransformField:
+		if field.Type != "Symbol" && field.Type != "Text" {
+			return fmt.Errorf("transform_field writes text, but field %s is of type %s", step.FieldID, field.Type)
+		}
+		tmpl, err := parseMigrationTemplate(step.Template)
+		if err != nil {
+			return err
+		}
+		transform = func(entry *contentful.Entry, locale, defaultLocale string) (interface{}, bool, error) {
+			data := make(map[string]interface{}, len(ct.Fields))
+			for _, f := range ct.Fields {
+				v, ok := localizedValue(entry, f, locale, defaultLocale)
+				if !ok {
+					v = ""
+				}
+				data[apiNameOf(f)] = v
+			}
+			var b strings.Builder
+			if err := tmpl.Execute(&b, data); err != nil {
+				return nil, false, err
+			}
+			return b.String(), b.Len() > 0, nil
+		}
+	}
+
+	defaultLocale, locales, err := migrationLocales(ctx, m.entities, env)
+	if err != nil {
+		return err
+	}
+	if !field.Localized {
+		locales = []string{defaultLocale}
+	}
+	return updateMigrationEntries(ctx, env, m, step, func(entry *contentful.Entry) (bool, error) {
+		values, _ := entry.Fields[step.FieldID].(map[string]interface{})
+		if values == nil {
+			values = map[string]interface{}{}
+		}
+		changed := false
+		for _, locale := range locales {
+			v, ok, err := transform(entry, locale, defaultLocale)
+			if err != nil {
+				return false, fmt.Errorf("locale %s: %w", locale, err)
+			}
+			if ok {
+				values[locale] = v
+				changed = true
+			}
+		}
+		if changed {
+			entry.Fields[step.FieldID] = values
+		}
+		return changed, nil
+	})
+}
+
+func findFieldByAPIName(fields []*ContentTypeField, id string) (*ContentTypeField, bool) {
+	for _, field := range fields {
+		if apiNameOf(field) == id {
+			return field, true
+		}
+	}
+	return nil, false
+}
+
+// localizedValue returns the value of the field of an entry in the locale. Fields which are not localized only
+// have a value in the default locale.
+func localizedValue(entry *contentful.Entry, field *ContentTypeField, locale, defaultLocale string) (interface{}, bool) {
+	if !field.Localized {
+		locale = defaultLocale
+	}
+	values, _ := entry.Fields[apiNameOf(field)].(map[string]interface{})
+	v, ok := values[locale]
+	return v, ok
+}
+
+// migrationLocales returns the code of the default locale and the codes of all locales of the environment.
+func migrationLocales(ctx context.Context, client ContentfulEnvironmentEntityClient, env *contentful.Environment) (string, []string, error) {
+	docs, err := client.List(ctx, env, "locales")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list locales: %w", err)
+	}
+
+	var defaultLocale string
+	locales := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		code := stringValue(doc["code"])
+		if doc["default"] == true {
+			defaultLocale = code
+		}
+		locales = append(locales, code)
+	}
+	return defaultLocale, locales, nil
+}
+
+// updateMigrationEntries applies update to every entry of the content type of the step. Entries which update
+// changes are saved, and published again when they were published. Archived entries cannot be changed, so they
+// are left as they are.
+func updateMigrationEntries(ctx context.Context, env *contentful.Environment, m *migrationClients, step *migrationStep, update func(entry *contentful.Entry) (bool, error)) error {
+	spaceID := env.Sys.Space.Sys.ID
+	for skip := 0; ; skip += contentTypeEntriesPageSize {
+		col, err := m.entries.ListByContentType(ctx, spaceID, env.Sys.ID, step.ContentTypeID, skip, contentTypeEntriesPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range col.Items {
+			status := entityStatus(entry.Sys)
+			if status == entityStatusArchived {
+				continue
+			}
+			if entry.Fields == nil {
+				entry.Fields = map[string]interface{}{}
+			}
+			changed, err := update(entry)
+			if err != nil {
+				return fmt.Errorf("entry %s: %w", entry.Sys.ID, err)
+			}
+			if !changed {
+				continue
+			}
+
+			if err := m.entries.Upsert(ctx, env, step.ContentTypeID, entry); err != nil {
+				return fmt.Errorf("failed to update entry %s: %w", entry.Sys.ID, err)
+			}
+			if status == entityStatusPublished {
+				if err := m.entries.Publish(ctx, env, entry); err != nil {
+					return fmt.Errorf("failed to publish entry %s: %w", entry.Sys.ID, err)
+				}
+			}
+		}
+
+		if skip+contentTypeEntriesPageSize >= col.Total {
+			return nil
+		}
+	}
+}
+
+// createMigrationField adds the field of the step to the content type. A field which already exists is left as
+// it is, so that a step which ran without being recorded succeeds when it runs again.
+func createMigrationField(ctx context.Context, env *contentful.Environment, m *migrationClients, ct *ContentType, step *migrationStep) error {
+	if _, ok := findFieldByAPIName(ct.Fields, step.FieldID); ok {
+		return nil
+	}
+
+	name := step.FieldName
+	if name == "" {
+		name = step.FieldID
+	}
+	ct.Fields = append(ct.Fields, &ContentTypeField{Field: contentful.Field{
+		ID:        step.FieldID,
+		Name:      name,
+		Type:      step.FieldType,
+		Localized: step.Localized,
+	}})
+	return upsertAndActivate(ctx, m.contentTypes, env, ct)
+}
+
+// deleteMigrationField omits the field of the step and deletes it, as Contentful only deletes omitted fields.
+func deleteMigrationField(ctx context.Context, env *contentful.Environment, m *migrationClients, ct *ContentType, step *migrationStep) error {
+	field, ok := findFieldByAPIName(ct.Fields, step.FieldID)
+	if !ok {
+		return nil
+	}
+	if apiNameOf(field) == ct.DisplayField || field.ID == ct.DisplayField {
+		return fmt.Errorf("field %s is the display field of content type %s", step.FieldID, step.ContentTypeID)
+	}
+
+	fields := make([]*ContentTypeField, 0, len(ct.Fields))
+	for _, f := range ct.Fields {
+		if f != field {
+			fields = append(fields, f)
+		}
+	}
+	firstApplyFields, _, _ := checkFieldsToOmit(ct.Fields, fields)
+	ct.Fields = firstApplyFields
+	ct.Metadata = withoutDeletedFieldAnnotations(ct.Metadata, ct.Fields)
+	if err := upsertAndActivate(ctx, m.contentTypes, env, ct); err != nil {
+		return err
+	}
+
+	ct.Fields = fields
+	ct.Metadata = withoutDeletedFieldAnnotations(ct.Metadata, ct.Fields)
+	return upsertAndActivate(ctx, m.contentTypes, env, ct)
+}
+
+var nonSlugCharacters = regexp.MustCompile(`[^a-z0-9]+`)
+
+// migrationTemplateFuncs are the functions of the templates of transform_field.
+var migrationTemplateFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+	"replace": func(old, new, s string) string {
+		return strings.ReplaceAll(s, old, new)
+	},
+	"slug": func(s string) string {
+		return strings.Trim(nonSlugCharacters.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	},
+}
+
+func parseMigrationTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("template").Funcs(migrationTemplateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
@@ -0,0 +1,207 @@
+package contentful
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+func TestValidateMigrationSteps(t *testing.T) {
+	tests := map[string]struct {
+		migrationID string
+		steps       []*migrationStep
+
+		expectErr string
+	}{
+		"valid steps should pass": {
+			migrationID: "slugs",
+			steps: []*migrationStep{
+				{ID: "create", Action: migrationActionCreateField},
+				{ID: "copy", Action: migrationActionCopyField, FromFieldID: "title"},
+				{ID: "transform", Action: migrationActionTransformField, Template: "{{ .title | slug }}"},
+			},
+		},
+		"duplicated step IDs should fail": {
+			migrationID: "slugs",
+			steps: []*migrationStep{
+				{ID: "create", Action: migrationActionCreateField},
+				{ID: "create", Action: migrationActionDeleteField},
+			},
+			expectErr: "step.1: step ID create is used twice",
+		},
+		"copy_field without from_field_id should fail": {
+			migrationID: "slugs",
+			steps:       []*migrationStep{{ID: "copy", Action: migrationActionCopyField}},
+			expectErr:   "step.0: from_field_id is required by copy_field",
+		},
+		"invalid template should fail": {
+			migrationID: "slugs",
+			steps:       []*migrationStep{{ID: "transform", Action: migrationActionTransformField, Template: "{{ .title | unknown }}"}},
+			expectErr:   `step.0: invalid template: template: template:1: function "unknown" not defined`,
+		},
+		"too long IDs should fail": {
+			migrationID: "a_migration_with_a_long_name_for_sure",
+			steps:       []*migrationStep{{ID: "and_a_long_step_name", Action: migrationActionCreateField}},
+			expectErr:   "step.0: migration_id and step ID and_a_long_step_name are too long to record the step, which takes a tag ID of up to 64 characters",
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			err := validateMigrationSteps(tt.migrationID, tt.steps)
+			var got string
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.expectErr {
+				t.Errorf("validateMigrationSteps() error = %q, expect %q", got, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestResourceMigration(t *testing.T) {
+	ctx := context.Background()
+	client, env := newFakeCMAEnvironment(t)
+	m := &migrationClients{
+		contentTypes: &contentTypesService{c: newCMAClient(client)},
+		entries:      newContentTypeEntriesClient(client),
+		entities:     &environmentEntitiesService{c: newCMAClient(client)},
+	}
+
+	if _, err := m.entities.Post(ctx, env, "locales", EntityDocument{"name": "German", "code": "de", "fallbackCode": "en-US"}); err != nil {
+		t.Fatal(err)
+	}
+	ct := &ContentType{
+		Sys:          &contentful.Sys{ID: "post"},
+		Name:         "Post",
+		DisplayField: "title",
+		Fields: []*ContentTypeField{
+			{Field: contentful.Field{ID: "title", Name: "Title", Type: "Symbol", Localized: true}},
+			{Field: contentful.Field{ID: "body", Name: "Body", Type: "Text"}},
+		},
+	}
+	if err := upsertAndActivate(ctx, m.contentTypes, env, ct); err != nil {
+		t.Fatal(err)
+	}
+	entries := map[string]map[string]interface{}{
+		"published": {"title": map[string]interface{}{"en-US": "Hello World", "de": "Hallo Welt"}, "body": map[string]interface{}{"en-US": "Body"}},
+		"draft":     {"title": map[string]interface{}{"en-US": "Draft Post!"}},
+	}
+	for id, fields := range entries {
+		entry := &contentful.Entry{Sys: &contentful.Sys{ID: id}, Fields: fields}
+		if err := m.entries.Upsert(ctx, env, "post", entry); err != nil {
+			t.Fatal(err)
+		}
+		if id == "published" {
+			if err := m.entries.Publish(ctx, env, entry); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	steps := []interface{}{
+		map[string]interface{}{"id": "create-slug", "action": migrationActionCreateField, "content_type_id": "post", "field_id": "slug", "field_name": "Slug", "localized": true},
+		map[string]interface{}{"id": "fill-slug", "action": migrationActionTransformField, "content_type_id": "post", "field_id": "slug", "template": "{{ .title | slug }}"},
+		map[string]interface{}{"id": "create-headline", "action": migrationActionCreateField, "content_type_id": "post", "field_id": "headline"},
+		map[string]interface{}{"id": "copy-headline", "action": migrationActionCopyField, "content_type_id": "post", "field_id": "headline", "from_field_id": "title"},
+		map[string]interface{}{"id": "delete-body", "action": migrationActionDeleteField, "content_type_id": "post", "field_id": "body"},
+	}
+	r := resourceContentfulMigration()
+	config := map[string]interface{}{"space_id": "space", "env_id": "master", "migration_id": "slugs", "step": steps}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := resourceMigrationCreate(ctx, d, env, m); diags.HasError() {
+		t.Fatalf("resourceMigrationCreate() diags = %v", diags)
+	}
+
+	expectApplied := []string{"create-slug", "fill-slug", "create-headline", "copy-headline", "delete-body"}
+	if diff := cmp.Diff(expectApplied, toStrings(d.Get("applied_steps").([]interface{}))); diff != "" {
+		t.Errorf("applied_steps diff (-expect, +got)\n%s", diff)
+	}
+
+	got, err := m.contentTypes.Get(ctx, env, "post")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fieldIDs []string
+	for _, f := range got.Fields {
+		fieldIDs = append(fieldIDs, f.ID)
+	}
+	if diff := cmp.Diff([]string{"title", "slug", "headline"}, fieldIDs); diff != "" {
+		t.Errorf("fields diff (-expect, +got)\n%s", diff)
+	}
+
+	expectEntries := map[string]struct {
+		fields map[string]interface{}
+		status string
+	}{
+		"published": {
+			fields: map[string]interface{}{
+				"title":    map[string]interface{}{"en-US": "Hello World", "de": "Hallo Welt"},
+				"slug":     map[string]interface{}{"en-US": "hello-world", "de": "hallo-welt"},
+				"headline": map[string]interface{}{"en-US": "Hello World"},
+			},
+			status: entityStatusPublished,
+		},
+		"draft": {
+			fields: map[string]interface{}{
+				"title":    map[string]interface{}{"en-US": "Draft Post!"},
+				"slug":     map[string]interface{}{"en-US": "draft-post"},
+				"headline": map[string]interface{}{"en-US": "Draft Post!"},
+			},
+			status: entityStatusDraft,
+		},
+	}
+	for id, expect := range expectEntries {
+		entry, err := m.entries.Get(ctx, env, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(expect.fields, entry.Fields); diff != "" {
+			t.Errorf("fields of entry %s diff (-expect, +got)\n%s", id, diff)
+		}
+		if status := entityStatus(entry.Sys); status != expect.status {
+			t.Errorf("status of entry %s = %s, expect %s", id, status, expect.status)
+		}
+	}
+
+	// Only the added step runs, even though the input of the steps which already ran changed.
+	draft, _ := m.entries.Get(ctx, env, "draft")
+	draft.Fields["title"] = map[string]interface{}{"en-US": "Renamed"}
+	if err := m.entries.Upsert(ctx, env, "post", draft); err != nil {
+		t.Fatal(err)
+	}
+	config["step"] = append(steps, map[string]interface{}{"id": "upper-headline", "action": migrationActionTransformField, "content_type_id": "post", "field_id": "headline", "template": "{{ .headline | upper }}"})
+	state := d.State()
+	diff, err := r.SimpleDiff(ctx, state, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceMigrationUpdate(ctx, d, env, m); diags.HasError() {
+		t.Fatalf("resourceMigrationUpdate() diags = %v", diags)
+	}
+
+	draft, err = m.entries.Get(ctx, env, "draft")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectDraft := map[string]interface{}{
+		"title":    map[string]interface{}{"en-US": "Renamed"},
+		"slug":     map[string]interface{}{"en-US": "draft-post"},
+		"headline": map[string]interface{}{"en-US": "DRAFT POST!"},
+	}
+	if diff := cmp.Diff(expectDraft, draft.Fields); diff != "" {
+		t.Errorf("fields of entry draft after update diff (-expect, +got)\n%s", diff)
+	}
+	if diff := cmp.Diff(append(expectApplied, "upper-headline"), toStrings(d.Get("applied_steps").([]interface{}))); diff != "" {
+		t.Errorf("applied_steps after update diff (-expect, +got)\n%s", diff)
+	}
+}
@@ -0,0 +1,262 @@
+package contentful
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+// releaseActionPollInterval is the interval to check whether a release action has completed.
+var releaseActionPollInterval = 2 * time.Second
+
+func resourceContentfulRelease() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a release, a group of entries and assets which are published together",
+		CreateContext: wrapRelease(resourceCreateRelease),
+		ReadContext:   wrapRelease(resourceReadRelease),
+		UpdateContext: wrapRelease(resourceUpdateRelease),
+		DeleteContext: wrapRelease(resourceDeleteRelease),
+		CustomizeDiff: setProviderDefaults,
+
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The current version of the release, which increases on every change",
+			},
+			"space_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the space. Defaults to `space_id` of the provider",
+			},
+			"env_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the environment. Defaults to `environment_id` of the provider",
+			},
+			"title": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The title of the release",
+			},
+			"entries": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the entries in the release",
+			},
+			"assets": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the assets in the release",
+			},
+			"action": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"publish", "validate"}, false)),
+				Description:      "The action to run on the release whenever it is created or updated: `publish` or `validate`",
+			},
+			"action_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the last action run on the release",
+			},
+		},
+	}
+}
+
+func wrapRelease(f func(ctx context.Context, d *schema.ResourceData, client ContentfulReleaseClient) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+		client := m.(*providerMeta).client
+		return f(ctx, d, &releasesService{c: newCMAClient(client)})
+	}
+}
+
+func resourceCreateRelease(ctx context.Context, d *schema.ResourceData, client ContentfulReleaseClient) (diags diag.Diagnostics) {
+	spaceID := d.Get("space_id").(string)
+	envID := d.Get("env_id").(string)
+
+	release := &Release{
+		Title:    d.Get("title").(string),
+		Entities: newEntityLinks(toStrings(d.Get("entries").([]interface{})), toStrings(d.Get("assets").([]interface{}))),
+	}
+
+	err := client.Upsert(ctx, spaceID, envID, release)
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	d.SetId(release.Sys.ID)
+
+	if err := setReleaseProperties(d, release); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	return runReleaseAction(ctx, d, client, release)
+}
+
+func resourceReadRelease(ctx context.Context, d *schema.ResourceData, client ContentfulReleaseClient) (diags diag.Diagnostics) {
+	spaceID := d.Get("space_id").(string)
+	envID := d.Get("env_id").(string)
+
+	release, err := client.Get(ctx, spaceID, envID, d.Id())
+	if _, ok := err.(contentful.NotFoundError); ok {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	if err := setReleaseProperties(d, release); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+	return
+}
+
+func resourceUpdateRelease(ctx context.Context, d *schema.ResourceData, client ContentfulReleaseClient) (diags diag.Diagnostics) {
+	spaceID := d.Get("space_id").(string)
+	envID := d.Get("env_id").(string)
+	defer func() {
+		if diags.HasError() {
+			d.Partial(true)
+		}
+	}()
+
+	release, err := client.Get(ctx, spaceID, envID, d.Id())
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	release.Title = d.Get("title").(string)
+	release.Entities = newEntityLinks(toStrings(d.Get("entries").([]interface{})), toStrings(d.Get("assets").([]interface{})))
+
+	err = client.Upsert(ctx, spaceID, envID, release)
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	if err := setReleaseProperties(d, release); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	return runReleaseAction(ctx, d, client, release)
+}
+
+func resourceDeleteRelease(ctx context.Context, d *schema.ResourceData, client ContentfulReleaseClient) (diags diag.Diagnostics) {
+	spaceID := d.Get("space_id").(string)
+	envID := d.Get("env_id").(string)
+
+	err := client.Delete(ctx, spaceID, envID, d.Id())
+	if _, ok := err.(contentful.NotFoundError); ok {
+		return nil
+	}
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+	return
+}
+
+// runReleaseAction publishes or validates the release as configured by action and waits until the action completes.
+func runReleaseAction(ctx context.Context, d *schema.ResourceData, client ContentfulReleaseClient, release *Release) (diags diag.Diagnostics) {
+	spaceID := d.Get("space_id").(string)
+	envID := d.Get("env_id").(string)
+
+	var action *ReleaseAction
+	var err error
+	switch d.Get("action").(string) {
+	case "publish":
+		action, err = client.Publish(ctx, spaceID, envID, release)
+	case "validate":
+		action, err = client.Validate(ctx, spaceID, envID, release)
+	default:
+		return nil
+	}
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	err = pollUntil(ctx, releaseActionPollInterval, func() (bool, error) {
+		if action.Sys.Status == "succeeded" || action.Sys.Status == "failed" {
+			return true, nil
+		}
+
+		action, err = client.GetAction(ctx, spaceID, envID, release.Sys.ID, action.Sys.ID)
+		return false, err
+	})
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	if err := d.Set("action_status", action.Sys.Status); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	if action.Sys.Status == "failed" {
+		if action.Error == nil {
+			action.Error = &ActionError{Message: "release action failed"}
+		}
+		diags = append(diags, actionErrorToDiagnostic(action.Error)...)
+		return
+	}
+	return
+}
+
+func setReleaseProperties(d *schema.ResourceData, release *Release) error {
+	if err := d.Set("version", release.Sys.Version); err != nil {
+		return err
+	}
+
+	if err := d.Set("title", release.Title); err != nil {
+		return err
+	}
+
+	entries := make([]string, 0)
+	assets := make([]string, 0)
+	for _, item := range release.Entities.Items {
+		switch item.Sys.LinkType {
+		case "Entry":
+			entries = append(entries, item.Sys.ID)
+		case "Asset":
+			assets = append(assets, item.Sys.ID)
+		}
+	}
+
+	if err := d.Set("entries", entries); err != nil {
+		return err
+	}
+
+	if err := d.Set("assets", assets); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func toStrings(values []interface{}) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = v.(string)
+	}
+	return result
+}
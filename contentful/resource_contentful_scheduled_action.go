@@ -0,0 +1,228 @@
+package contentful
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+func resourceContentfulScheduledAction() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Schedules publishing or unpublishing an entry or asset. Destroying the resource cancels the scheduled action",
+		CreateContext: wrapScheduledAction(resourceCreateScheduledAction),
+		ReadContext:   wrapScheduledAction(resourceReadScheduledAction),
+		DeleteContext: wrapScheduledAction(resourceDeleteScheduledAction),
+		CustomizeDiff: setProviderDefaults,
+
+		Schema: map[string]*schema.Schema{
+			"space_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the space. Defaults to `space_id` of the provider",
+			},
+			"env_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The ID of the environment. Defaults to `environment_id` of the provider",
+			},
+			"entity_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the entry or asset",
+			},
+			"entity_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "Entry",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"Entry", "Asset"}, false)),
+				Description:      "The type of the entity: `Entry` or `Asset`",
+			},
+			"action": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"publish", "unpublish"}, false)),
+				Description:      "The action to run: `publish` or `unpublish`",
+			},
+			"scheduled_for": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+				DiffSuppressFunc: suppressEquivalentTime,
+				Description:      "The time to run the action at, in RFC 3339 format",
+			},
+			"timezone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The time zone which the web app shows the time in, such as `Europe/Berlin`",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the scheduled action, such as `scheduled`, `succeeded`, `failed` or `canceled`",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The current version of the scheduled action, which increases on every change",
+			},
+		},
+	}
+}
+
+func wrapScheduledAction(f func(ctx context.Context, d *schema.ResourceData, client ContentfulScheduledActionClient) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+		client := m.(*providerMeta).client
+		return f(ctx, d, &scheduledActionsService{c: newCMAClient(client)})
+	}
+}
+
+func resourceCreateScheduledAction(ctx context.Context, d *schema.ResourceData, client ContentfulScheduledActionClient) (diags diag.Diagnostics) {
+	spaceID := d.Get("space_id").(string)
+
+	scheduledAction := &ScheduledAction{
+		Entity: contentful.Entity{
+			Sys: contentful.Sys{
+				Type:     "Link",
+				LinkType: d.Get("entity_type").(string),
+				ID:       d.Get("entity_id").(string),
+			},
+		},
+		Environment: contentful.EnvironmentLink{
+			Sys: contentful.Sys{
+				Type:     "Link",
+				LinkType: "Environment",
+				ID:       d.Get("env_id").(string),
+			},
+		},
+		ScheduledFor: ScheduledActionScheduledFor{
+			Datetime: d.Get("scheduled_for").(string),
+			Timezone: d.Get("timezone").(string),
+		},
+		Action: d.Get("action").(string),
+	}
+
+	err := client.Create(ctx, spaceID, scheduledAction)
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	if err := setScheduledActionProperties(d, scheduledAction); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	d.SetId(scheduledAction.Sys.ID)
+
+	return nil
+}
+
+func resourceReadScheduledAction(ctx context.Context, d *schema.ResourceData, client ContentfulScheduledActionClient) (diags diag.Diagnostics) {
+	spaceID := d.Get("space_id").(string)
+	envID := d.Get("env_id").(string)
+
+	scheduledAction, err := client.Get(ctx, spaceID, envID, d.Id())
+	if _, ok := err.(contentful.NotFoundError); ok {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	// A canceled action will never run, so let Terraform schedule it again.
+	if scheduledAction.Sys.Status == "canceled" {
+		d.SetId("")
+		return nil
+	}
+
+	if err := setScheduledActionProperties(d, scheduledAction); err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+	return
+}
+
+func resourceDeleteScheduledAction(ctx context.Context, d *schema.ResourceData, client ContentfulScheduledActionClient) (diags diag.Diagnostics) {
+	spaceID := d.Get("space_id").(string)
+	envID := d.Get("env_id").(string)
+
+	scheduledAction, err := client.Get(ctx, spaceID, envID, d.Id())
+	if _, ok := err.(contentful.NotFoundError); ok {
+		return nil
+	}
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+
+	// Only pending actions can be canceled. Executed ones are kept in the history of the entity.
+	if scheduledAction.Sys.Status != "scheduled" {
+		return nil
+	}
+
+	err = client.Cancel(ctx, spaceID, envID, d.Id())
+	if err != nil {
+		diags = append(diags, contentfulErrorToDiagnostic(err)...)
+		return
+	}
+	return
+}
+
+func setScheduledActionProperties(d *schema.ResourceData, scheduledAction *ScheduledAction) error {
+	if err := d.Set("entity_id", scheduledAction.Entity.Sys.ID); err != nil {
+		return err
+	}
+
+	if err := d.Set("entity_type", scheduledAction.Entity.Sys.LinkType); err != nil {
+		return err
+	}
+
+	if err := d.Set("action", scheduledAction.Action); err != nil {
+		return err
+	}
+
+	if err := d.Set("scheduled_for", scheduledAction.ScheduledFor.Datetime); err != nil {
+		return err
+	}
+
+	if err := d.Set("status", scheduledAction.Sys.Status); err != nil {
+		return err
+	}
+
+	if err := d.Set("version", scheduledAction.Sys.Version); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// suppressEquivalentTime ignores differences in the formatting of the same point in time,
+// because the API normalizes datetimes to UTC with milliseconds.
+func suppressEquivalentTime(k, old, new string, d *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+
+	return oldTime.Equal(newTime)
+}
@@ -0,0 +1,39 @@
+package contentful
+
+import (
+	"testing"
+)
+
+func TestSuppressEquivalentTime(t *testing.T) {
+	tests := map[string]struct {
+		old string
+		new string
+
+		expect bool
+	}{
+		"same time in a different format": {
+			old:    "2099-01-01T00:00:00.000Z",
+			new:    "2099-01-01T01:00:00+01:00",
+			expect: true,
+		},
+		"different time": {
+			old:    "2099-01-01T00:00:00.000Z",
+			new:    "2099-01-02T00:00:00Z",
+			expect: false,
+		},
+		"invalid time": {
+			old:    "",
+			new:    "2099-01-02T00:00:00Z",
+			expect: false,
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			got := suppressEquivalentTime("scheduled_for", tt.old, tt.new, nil)
+			if got != tt.expect {
+				t.Errorf("suppressEquivalentTime(%q, %q) = %v, expect %v", tt.old, tt.new, got, tt.expect)
+			}
+		})
+	}
+}
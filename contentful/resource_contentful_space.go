@@ -10,6 +10,7 @@ import (
 
 func resourceContentfulSpace() *schema.Resource {
 	return &schema.Resource{
+		Description:   "Manages a space of the organization",
 		CreateContext: wrapSpace(resourceSpaceCreate),
 		ReadContext:   wrapSpace(resourceSpaceRead),
 		UpdateContext: wrapSpace(resourceSpaceUpdate),
@@ -17,18 +18,21 @@ func resourceContentfulSpace() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"version": {
-				Type:     schema.TypeInt,
-				Computed: true,
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The current version of the space, which increases on every change",
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the space",
 			},
 			// Space specific props
 			"default_locale": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "en",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "en",
+				Description: "The code of the default locale of the space",
 			},
 		},
 	}
@@ -36,7 +40,7 @@ func resourceContentfulSpace() *schema.Resource {
 
 func wrapSpace(f func(ctx context.Context, d *schema.ResourceData, client ContentfulSpaceClient) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-		client := m.(*contentful.Client)
+		client := m.(*providerMeta).client
 		return f(ctx, d, client.Spaces)
 	}
 }
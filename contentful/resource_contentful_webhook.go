@@ -10,50 +10,62 @@ import (
 
 func resourceContentfulWebhook() *schema.Resource {
 	return &schema.Resource{
+		Description:   "Manages a webhook of a space",
 		CreateContext: wrapWebhook(resourceCreateWebhook),
 		ReadContext:   wrapWebhook(resourceReadWebhook),
 		UpdateContext: wrapWebhook(resourceUpdateWebhook),
 		DeleteContext: wrapWebhook(resourceDeleteWebhook),
+		Importer:      importSpaceEntity(),
+		CustomizeDiff: setProviderDefaults,
 
 		Schema: map[string]*schema.Schema{
 			"version": {
-				Type:     schema.TypeInt,
-				Computed: true,
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The current version of the webhook, which increases on every change",
 			},
 			"space_id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the space. Defaults to `space_id` of the provider",
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the webhook",
 			},
 			// Webhook specific props
 			"url": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The URL which requests are sent to",
 			},
 			"http_basic_auth_username": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The user name of HTTP basic authentication",
 			},
 			"http_basic_auth_password": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The password of HTTP basic authentication",
 			},
 			"headers": {
-				Type:     schema.TypeMap,
-				Optional: true,
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The headers sent with each request",
 			},
 			"topics": {
 				Type: schema.TypeList,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				MinItems: 1,
-				Required: true,
+				MinItems:    1,
+				Required:    true,
+				Description: "The events which trigger the webhook, such as `Entry.publish` or `*.*`",
 			},
 		},
 	}
@@ -61,7 +73,7 @@ func resourceContentfulWebhook() *schema.Resource {
 
 func wrapWebhook(f func(ctx context.Context, d *schema.ResourceData, client ContentfulWebhookClient) diag.Diagnostics) func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
-		client := m.(*contentful.Client)
+		client := m.(*providerMeta).client
 		return f(ctx, d, client.Webhooks)
 	}
 }
@@ -0,0 +1,11 @@
+data "contentful_contenttype_go" "blog" {
+  space_id         = "space-id"
+  env_id           = "environment-name"
+  content_type_ids = ["blogPost", "author"]
+  package_name     = "models"
+}
+
+resource "local_file" "blog_types" {
+  filename = "${path.module}/models/contentful.go"
+  content  = data.contentful_contenttype_go.blog.content
+}
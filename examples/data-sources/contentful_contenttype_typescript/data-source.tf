@@ -0,0 +1,10 @@
+data "contentful_contenttype_typescript" "blog" {
+  space_id         = "space-id"
+  env_id           = "environment-name"
+  content_type_ids = ["blogPost", "author"]
+}
+
+resource "local_file" "blog_types" {
+  filename = "${path.module}/src/types/contentful.ts"
+  content  = data.contentful_contenttype_typescript.blog.content
+}
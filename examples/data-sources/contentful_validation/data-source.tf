@@ -0,0 +1,42 @@
+data "contentful_validation" "title_size" {
+  size {
+    min = 1
+    max = 20
+  }
+  message = "The title must be 1 to 20 characters long"
+}
+
+data "contentful_validation" "linked_posts" {
+  link_content_type = ["post"]
+}
+
+data "contentful_validation" "rating_values" {
+  in_numbers = [1, 2, 3, 4, 5]
+}
+
+resource "contentful_contenttype" "example_contenttype" {
+  space_id      = "space-id"
+  env_id        = "environment-name"
+  name          = "Example"
+  display_field = "title"
+
+  field {
+    id          = "title"
+    name        = "Title"
+    type        = "Symbol"
+    validations = [data.contentful_validation.title_size.json]
+  }
+  field {
+    id          = "related"
+    name        = "Related"
+    type        = "Link"
+    link_type   = "Entry"
+    validations = [data.contentful_validation.linked_posts.json]
+  }
+  field {
+    id          = "rating"
+    name        = "Rating"
+    type        = "Integer"
+    validations = [data.contentful_validation.rating_values.json]
+  }
+}
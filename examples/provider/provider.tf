@@ -0,0 +1,6 @@
+provider "contentful" {
+  cma_token       = "<your CMA Token>"
+  organization_id = "<your organization ID>"
+  space_id        = "<your space ID>"
+  environment_id  = "master"
+}
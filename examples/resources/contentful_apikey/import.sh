@@ -0,0 +1,2 @@
+# API keys are imported by the space ID and the API key ID.
+terraform import contentful_apikey.example <space_id>/<api_key_id>
@@ -0,0 +1,2 @@
+# Assets are imported by the space ID and the asset ID.
+terraform import contentful_asset.example <space_id>/<asset_id>
@@ -0,0 +1,7 @@
+resource "contentful_bulk_action" "example_bulk_action" {
+  space_id = "space-id"
+  env_id   = "master"
+  action   = "publish"
+  entries  = [for entry in contentful_entry.countries : entry.id]
+  assets   = [contentful_asset.flag.id]
+}
@@ -0,0 +1,2 @@
+# Content types are imported by the space ID, the environment ID and the content type ID.
+terraform import contentful_contenttype.example <space_id>/<env_id>/<content_type_id>
@@ -5,6 +5,7 @@ resource "contentful_contenttype" "example_contenttype" {
   display_field   = "asset_field"
   content_type_id = "exampleContentType"
   env_id          = "environment-name"
+  annotations     = ["Contentful:AggregateRoot"]
 
   field {
     id   = "asset_field"
@@ -30,4 +31,31 @@ resource "contentful_contenttype" "example_contenttype" {
     ]
     required = false
   }
+  field {
+    id   = "featured"
+    name = "Featured"
+    type = "Boolean"
+    default_value = {
+      "en-US" = "false"
+    }
+  }
+  field {
+    id   = "related_entries"
+    name = "Related Entries"
+    type = "ResourceLink"
+    allowed_resources {
+      source        = "crn:contentful:::content:spaces/other-space-id"
+      content_types = ["article"]
+    }
+  }
+}
+
+# The fields can also be defined by the JSON of a content type, such as an export of it.
+resource "contentful_contenttype" "from_definition" {
+  space_id        = "space-id"
+  env_id          = "environment-name"
+  name            = "Article"
+  display_field   = "title"
+  content_type_id = "article"
+  definition_json = file("${path.module}/article.json")
 }
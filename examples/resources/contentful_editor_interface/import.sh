@@ -0,0 +1,2 @@
+# Editor interfaces are imported by the space ID, the environment ID and the content type ID.
+terraform import contentful_editor_interface.example <space_id>/<env_id>/<content_type_id>
@@ -0,0 +1,21 @@
+resource "contentful_editor_interface" "post" {
+  space_id        = "space-id"
+  env_id          = "master"
+  content_type_id = contentful_contenttype.post.content_type_id
+
+  control {
+    field_id  = "body"
+    widget_id = "markdown"
+  }
+
+  control {
+    field_id      = "slug"
+    widget_id     = "slugEditor"
+    settings_json = jsonencode({ helpText = "Generated from the title" })
+  }
+
+  sidebar {
+    widget_namespace = "sidebar-builtin"
+    widget_id        = "publication-widget"
+  }
+}
@@ -0,0 +1,30 @@
+resource "contentful_entries" "countries" {
+  space_id        = "space-id"
+  env_id          = "master"
+  content_type_id = "country"
+
+  entries_json = jsonencode([
+    {
+      id = "jp"
+      fields = {
+        name = { "en-US" = "Japan" }
+        code = { "en-US" = "JP" }
+      }
+    },
+    {
+      id = "de"
+      fields = {
+        name = { "en-US" = "Germany" }
+        code = { "en-US" = "DE" }
+      }
+    },
+  ])
+}
+
+# The entries can also be kept in a YAML document.
+resource "contentful_entries" "categories" {
+  space_id        = "space-id"
+  env_id          = "master"
+  content_type_id = "category"
+  entries_json    = jsonencode(yamldecode(file("${path.module}/categories.yaml")))
+}
@@ -0,0 +1,2 @@
+# Entries are imported by the space ID, the environment ID and the entry ID.
+terraform import contentful_entry.example <space_id>/<env_id>/<entry_id>
@@ -1,4 +1,9 @@
 resource "contentful_environment" "example_environment" {
   space_id = "spaced-id"
   name     = "environment-name"
+
+  # Copying a large master environment can take longer than the default of 20 minutes.
+  timeouts {
+    create = "40m"
+  }
 }
@@ -0,0 +1,6 @@
+# contentful space export --space-id <space_id> --environment-id master --content-file export.json
+resource "contentful_environment_content" "staging" {
+  space_id = "space-id"
+  env_id   = "staging"
+  file     = "${path.module}/export.json"
+}
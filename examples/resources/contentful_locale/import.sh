@@ -0,0 +1,2 @@
+# Locales are imported by the space ID and the locale ID.
+terraform import contentful_locale.example <space_id>/<locale_id>
@@ -0,0 +1,3 @@
+# Migrations are imported by the space ID, the environment ID and the migration ID. The steps are not imported,
+# so configure them before the next apply, which runs the steps which have not run yet.
+terraform import contentful_migration.example <space_id>/<env_id>/<migration_id>
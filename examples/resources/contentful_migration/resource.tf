@@ -0,0 +1,45 @@
+resource "contentful_migration" "post_slugs" {
+  space_id     = "space-id"
+  env_id       = "master"
+  migration_id = "post_slugs"
+
+  step {
+    id              = "create-slug"
+    action          = "create_field"
+    content_type_id = "post"
+    field_id        = "slug"
+    field_name      = "Slug"
+    localized       = true
+  }
+
+  step {
+    id              = "fill-slug"
+    action          = "transform_field"
+    content_type_id = "post"
+    field_id        = "slug"
+    template        = "{{ .title | slug }}"
+  }
+
+  step {
+    id              = "create-summary"
+    action          = "create_field"
+    content_type_id = "post"
+    field_id        = "summary"
+    field_type      = "Text"
+  }
+
+  step {
+    id              = "copy-summary"
+    action          = "copy_field"
+    content_type_id = "post"
+    field_id        = "summary"
+    from_field_id   = "description"
+  }
+
+  step {
+    id              = "delete-description"
+    action          = "delete_field"
+    content_type_id = "post"
+    field_id        = "description"
+  }
+}
@@ -0,0 +1,13 @@
+resource "contentful_release" "example_release" {
+  space_id = "space-id"
+  env_id   = "master"
+  title    = "Spring campaign"
+  entries = [
+    contentful_entry.landing_page.id,
+    contentful_entry.hero_banner.id,
+  ]
+  assets = [
+    contentful_asset.hero_image.id,
+  ]
+  action = "publish"
+}
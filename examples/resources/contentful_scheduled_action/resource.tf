@@ -0,0 +1,9 @@
+resource "contentful_scheduled_action" "example_scheduled_action" {
+  space_id      = "space-id"
+  env_id        = "master"
+  entity_id     = contentful_entry.example_entry.id
+  entity_type   = "Entry"
+  action        = "publish"
+  scheduled_for = "2030-01-01T09:00:00+01:00"
+  timezone      = "Europe/Berlin"
+}
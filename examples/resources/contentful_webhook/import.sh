@@ -0,0 +1,2 @@
+# Webhooks are imported by the space ID and the webhook ID.
+terraform import contentful_webhook.example <space_id>/<webhook_id>
@@ -0,0 +1,271 @@
+package fakecma
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// maxActionItems is the maximum number of entities of a release or bulk action.
+const maxActionItems = 200
+
+func (s *Server) handleRelease(r *request, envPath string, rest []string) (int, interface{}, error) {
+	collection := envPath + "/releases"
+
+	if len(rest) == 0 {
+		if r.Method != http.MethodPost {
+			return 0, nil, errNotFound()
+		}
+		if err := validateRelease(r.body); err != nil {
+			return 0, nil, err
+		}
+		id := newID()
+		release := document{
+			"sys":      newSys(envPath, "Release", id),
+			"title":    r.body["title"],
+			"entities": r.body["entities"],
+		}
+		s.put(collection, id, release)
+		return http.StatusCreated, release, nil
+	}
+
+	release := s.get(collection, rest[0])
+	if release == nil {
+		return 0, nil, errNotFound()
+	}
+
+	switch {
+	case len(rest) == 1 && r.Method == http.MethodGet:
+		return http.StatusOK, release, nil
+	case len(rest) == 1 && r.Method == http.MethodPut:
+		if err := checkVersion(r, release); err != nil {
+			return 0, nil, err
+		}
+		if err := validateRelease(r.body); err != nil {
+			return 0, nil, err
+		}
+		release["title"] = r.body["title"]
+		release["entities"] = r.body["entities"]
+		touch(release)
+		return http.StatusOK, release, nil
+	case len(rest) == 1 && r.Method == http.MethodDelete:
+		s.deleteTree(collection, rest[0])
+		return http.StatusNoContent, nil, nil
+	case len(rest) == 2 && rest[1] == "published" && r.Method == http.MethodPut:
+		if err := checkVersion(r, release); err != nil {
+			return 0, nil, err
+		}
+		return http.StatusAccepted, s.createReleaseAction(envPath, release, "publish"), nil
+	case len(rest) == 2 && rest[1] == "validate" && r.Method == http.MethodPost:
+		return http.StatusAccepted, s.createReleaseAction(envPath, release, "validate"), nil
+	case len(rest) == 3 && rest[1] == "actions" && r.Method == http.MethodGet:
+		action := s.get(collection+"/"+rest[0]+"/actions", rest[2])
+		if action == nil {
+			return 0, nil, errNotFound()
+		}
+		return http.StatusOK, action, nil
+	}
+	return 0, nil, errNotFound()
+}
+
+func validateRelease(body document) error {
+	if str(body["title"]) == "" {
+		return errValidationFailed(errorDetail{Name: "required", Path: []interface{}{"title"}, Details: "The property \"title\" is required here"})
+	}
+	return validateActionItems(body)
+}
+
+func validateActionItems(body document) error {
+	entities, _ := body["entities"].(map[string]interface{})
+	items, _ := entities["items"].([]interface{})
+	if len(items) > maxActionItems {
+		return errValidationFailed(errorDetail{Name: "size", Path: []interface{}{"entities", "items"}, Details: fmt.Sprintf("Size must be at most %d", maxActionItems), Value: len(items)})
+	}
+	return nil
+}
+
+func (s *Server) createReleaseAction(envPath string, release document, action string) document {
+	releaseID := idOf(release)
+	sys := newSys(envPath, "ReleaseAction", newID())
+	sys["release"] = link("Release", releaseID)
+	doc := document{"sys": sys, "action": action}
+	s.runAction(envPath, doc, action, release["entities"])
+	s.put(envPath+"/releases/"+releaseID+"/actions", idOf(doc), doc)
+	return doc
+}
+
+func (s *Server) handleBulkAction(r *request, envPath string, rest []string) (int, interface{}, error) {
+	collection := envPath + "/bulk_actions"
+
+	switch {
+	case len(rest) == 1 && r.Method == http.MethodPost:
+		action := rest[0]
+		if action != "publish" && action != "unpublish" && action != "validate" {
+			return 0, nil, errNotFound()
+		}
+		if err := validateActionItems(r.body); err != nil {
+			return 0, nil, err
+		}
+		doc := document{
+			"sys":     newSys(envPath, "BulkAction", newID()),
+			"action":  action,
+			"payload": r.body,
+		}
+		s.runAction(envPath, doc, action, r.body["entities"])
+		s.put(collection, idOf(doc), doc)
+		return http.StatusCreated, doc, nil
+	case len(rest) == 2 && rest[0] == "actions" && r.Method == http.MethodGet:
+		doc := s.get(collection, rest[1])
+		if doc == nil {
+			return 0, nil, errNotFound()
+		}
+		return http.StatusOK, doc, nil
+	}
+	return 0, nil, errNotFound()
+}
+
+// runAction publishes, unpublishes or validates the linked entities of a release or bulk action.
+// Like the API, it changes nothing unless every entity can be processed, and records the outcome on doc.
+func (s *Server) runAction(envPath string, doc document, action string, entities interface{}) {
+	type target struct {
+		typ string
+		doc document
+	}
+
+	var targets []target
+	var errs []document
+	entityLinks, _ := entities.(map[string]interface{})
+	items, _ := entityLinks["items"].([]interface{})
+	for _, item := range items {
+		entityLink, _ := item.(map[string]interface{})
+		itemSys := sysOf(entityLink)
+		typ := str(itemSys["linkType"])
+		collection := envPath + "/entries"
+		if typ == "Asset" {
+			collection = envPath + "/assets"
+		}
+
+		entity := s.get(collection, str(itemSys["id"]))
+		var err *apiError
+		switch {
+		case entity == nil:
+			err = errNotFound()
+		case action == "unpublish" && !isPublished(entity):
+			err = errBadRequest("Not published")
+		case action != "unpublish" && isArchived(entity):
+			err = errBadRequest("Cannot publish archived entity")
+		case action == "publish" && itemSys["version"] != nil && intOf(itemSys["version"]) != intOf(sysOf(entity)["version"]):
+			err = errVersionMismatch()
+		case action != "unpublish":
+			if details := s.validateForPublish(envPath, typ, entity); len(details) > 0 {
+				err = errValidationFailed(details...)
+			}
+		}
+		if err != nil {
+			errs = append(errs, document{
+				"entity": link(typ, str(itemSys["id"])),
+				"error":  err.response(),
+			})
+			continue
+		}
+		targets = append(targets, target{typ: typ, doc: entity})
+	}
+
+	sys := sysOf(doc)
+	if len(errs) > 0 {
+		sys["status"] = "failed"
+		doc["error"] = document{
+			"sys":     document{"type": "Error", "id": "BulkActionFailed"},
+			"message": "Not all entities could be processed",
+			"details": document{"errors": errs},
+		}
+		return
+	}
+
+	for _, t := range targets {
+		switch action {
+		case "publish":
+			_ = s.publish(envPath, t.typ, t.doc)
+		case "unpublish":
+			_ = s.unpublish(envPath, t.typ, t.doc)
+		}
+	}
+	sys["status"] = "succeeded"
+}
+
+func (s *Server) handleScheduledAction(r *request, spaceID string, rest []string) (int, interface{}, error) {
+	spacePath := "/spaces/" + spaceID
+	collection := spacePath + "/scheduled_actions"
+
+	if len(rest) == 0 {
+		if r.Method != http.MethodPost {
+			return 0, nil, errNotFound()
+		}
+		if err := s.validateScheduledAction(spacePath, r.body); err != nil {
+			return 0, nil, err
+		}
+		id := newID()
+		sys := newSys(spacePath, "ScheduledAction", id)
+		sys["status"] = "scheduled"
+		doc := document{
+			"sys":          sys,
+			"entity":       r.body["entity"],
+			"environment":  r.body["environment"],
+			"scheduledFor": r.body["scheduledFor"],
+			"action":       r.body["action"],
+		}
+		s.put(collection, id, doc)
+		return http.StatusCreated, doc, nil
+	}
+
+	doc := s.get(collection, rest[0])
+	if len(rest) != 1 || doc == nil || idOf(doc["environment"]) != r.URL.Query().Get("environment.sys.id") {
+		return 0, nil, errNotFound()
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		return http.StatusOK, doc, nil
+	case http.MethodDelete:
+		sys := sysOf(doc)
+		if sys["status"] != "scheduled" {
+			return 0, nil, errBadRequest("Only scheduled actions which have not run yet can be canceled")
+		}
+		sys["status"] = "canceled"
+		touch(doc)
+		return http.StatusOK, doc, nil
+	}
+	return 0, nil, errNotFound()
+}
+
+func (s *Server) validateScheduledAction(spacePath string, body document) error {
+	envID := idOf(body["environment"])
+	if s.get(spacePath+"/environments", envID) == nil {
+		return errValidationFailed(errorDetail{Name: "notResolvable", Path: []interface{}{"environment"}, Details: fmt.Sprintf("The environment %q does not exist", envID)})
+	}
+
+	var details []errorDetail
+	entity, _ := body["entity"].(map[string]interface{})
+	collection := spacePath + "/environments/" + envID + "/entries"
+	if sysOf(entity)["linkType"] == "Asset" {
+		collection = spacePath + "/environments/" + envID + "/assets"
+	}
+	if s.get(collection, idOf(entity)) == nil {
+		details = append(details, errorDetail{Name: "notResolvable", Path: []interface{}{"entity"}, Details: fmt.Sprintf("The entity %q does not exist", idOf(entity))})
+	}
+
+	if action := str(body["action"]); action != "publish" && action != "unpublish" {
+		details = append(details, errorDetail{Name: "in", Path: []interface{}{"action"}, Details: "The action must be one of publish, unpublish", Value: action})
+	}
+
+	scheduledFor, _ := body["scheduledFor"].(map[string]interface{})
+	datetime, err := time.Parse(time.RFC3339, str(scheduledFor["datetime"]))
+	if err != nil || !datetime.After(time.Now()) {
+		details = append(details, errorDetail{Name: "range", Path: []interface{}{"scheduledFor", "datetime"}, Details: "The datetime must be in the future", Value: scheduledFor["datetime"]})
+	}
+
+	if len(details) > 0 {
+		return errValidationFailed(details...)
+	}
+	return nil
+}
@@ -0,0 +1,319 @@
+package fakecma
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// validateDocument checks a new or updated document in the collection below parent, as the API does on save.
+func (s *Server) validateDocument(parent, typ string, doc, body document) error {
+	var details []errorDetail
+	required := func(name string) {
+		if str(body[name]) == "" {
+			details = append(details, errorDetail{Name: "required", Path: []interface{}{name}, Details: fmt.Sprintf("The property %q is required here", name)})
+		}
+	}
+
+	switch typ {
+	case "Entry":
+		contentTypeID := idOf(sysOf(doc)["contentType"])
+		ct := s.get(parent+"/content_types", contentTypeID)
+		if ct == nil {
+			return errValidationFailed(errorDetail{Name: "notResolvable", Path: []interface{}{"sys", "contentType"}, Details: fmt.Sprintf("The content type %q does not exist", contentTypeID)})
+		}
+		locales := s.localeCodes(parent)
+		fields, _ := body["fields"].(map[string]interface{})
+		for id, v := range fields {
+			if contentTypeField(ct, id) == nil {
+				details = append(details, errorDetail{Name: "unknown", Path: []interface{}{"fields", id}, Details: fmt.Sprintf("The property %q is not expected", id)})
+				continue
+			}
+			values, _ := v.(map[string]interface{})
+			for locale := range values {
+				if !locales[locale] {
+					details = append(details, errorDetail{Name: "unknown", Path: []interface{}{"fields", id, locale}, Details: fmt.Sprintf("The locale %q does not exist", locale)})
+				}
+			}
+		}
+	case "ContentType":
+		required("name")
+		fieldIDs := make(map[string]bool)
+		fields, _ := body["fields"].([]interface{})
+		for i, v := range fields {
+			field, _ := v.(map[string]interface{})
+			id := str(field["id"])
+			if id == "" {
+				details = append(details, errorDetail{Name: "required", Path: []interface{}{"fields", i, "id"}, Details: "The property \"id\" is required here"})
+				continue
+			}
+			if fieldIDs[id] {
+				details = append(details, errorDetail{Name: "taken", Path: []interface{}{"fields", i, "id"}, Details: fmt.Sprintf("The field id %q is used by another field", id), Value: id})
+			}
+			fieldIDs[id] = true
+		}
+		if displayField := str(body["displayField"]); displayField != "" && !fieldIDs[displayField] {
+			details = append(details, errorDetail{Name: "notResolvable", Path: []interface{}{"displayField"}, Details: fmt.Sprintf("The display field %q does not exist", displayField), Value: displayField})
+		}
+	case "Locale":
+		required("code")
+		for _, locale := range s.sorted(parent + "/locales") {
+			if locale["code"] == body["code"] && idOf(locale) != idOf(doc) {
+				details = append(details, errorDetail{Name: "taken", Path: []interface{}{"code"}, Details: fmt.Sprintf("The locale %q already exists", str(body["code"])), Value: body["code"]})
+			}
+		}
+	case "WebhookDefinition":
+		required("name")
+		required("url")
+	case "ApiKey":
+		required("name")
+	case "Tag":
+		required("name")
+		for _, tag := range s.sorted(parent + "/tags") {
+			if tag["name"] == body["name"] && idOf(tag) != idOf(doc) {
+				details = append(details, errorDetail{Name: "taken", Path: []interface{}{"name"}, Details: fmt.Sprintf("The tag name %q already exists", str(body["name"])), Value: body["name"]})
+			}
+		}
+	}
+
+	if len(details) > 0 {
+		return errValidationFailed(details...)
+	}
+	return nil
+}
+
+// validateForPublish returns why the entry or asset cannot be published.
+func (s *Server) validateForPublish(parent, typ string, doc document) []errorDetail {
+	var details []errorDetail
+	fields, _ := doc["fields"].(map[string]interface{})
+
+	switch typ {
+	case "Entry":
+		contentTypeID := idOf(sysOf(doc)["contentType"])
+		ct := s.get(parent+"/content_types", contentTypeID)
+		if ct == nil || !isPublished(ct) {
+			return []errorDetail{{Name: "notResolvable", Path: []interface{}{"sys", "contentType"}, Details: fmt.Sprintf("The content type %q is not active", contentTypeID)}}
+		}
+		defaultLocale := s.defaultLocale(parent)
+		ctFields, _ := ct["fields"].([]interface{})
+		for _, v := range ctFields {
+			field, _ := v.(map[string]interface{})
+			if field["required"] != true || field["omitted"] == true || field["disabled"] == true {
+				continue
+			}
+			id := fieldAPIName(field)
+			values, _ := fields[id].(map[string]interface{})
+			if values[defaultLocale] == nil {
+				details = append(details, errorDetail{Name: "required", Path: []interface{}{"fields", id, defaultLocale}, Details: fmt.Sprintf("The property %q is required here", id)})
+			}
+		}
+	case "Asset":
+		files, _ := fields["file"].(map[string]interface{})
+		if len(files) == 0 {
+			details = append(details, errorDetail{Name: "required", Path: []interface{}{"fields", "file"}, Details: "The property \"file\" is required here"})
+		}
+		for locale, v := range files {
+			file, _ := v.(map[string]interface{})
+			if str(file["url"]) == "" {
+				details = append(details, errorDetail{Name: "required", Path: []interface{}{"fields", "file", locale, "url"}, Details: "The file has not been processed"})
+			}
+		}
+	}
+	return details
+}
+
+func (s *Server) publish(parent, typ string, doc document) error {
+	if typ != "ContentType" {
+		if isArchived(doc) {
+			return errBadRequest("Cannot publish archived " + strings.ToLower(typ))
+		}
+		if details := s.validateForPublish(parent, typ, doc); len(details) > 0 {
+			return errValidationFailed(details...)
+		}
+	}
+
+	if typ == "ContentType" {
+		s.dropDeletedFields(parent, doc)
+	}
+
+	sys := sysOf(doc)
+	now := timestamp()
+	sys["publishedVersion"] = intOf(sys["version"])
+	sys["publishedAt"] = now
+	sys["publishedCounter"] = intOf(sys["publishedCounter"]) + 1
+	if str(sys["firstPublishedAt"]) == "" {
+		sys["firstPublishedAt"] = now
+	}
+	touch(doc)
+	return nil
+}
+
+// dropDeletedFields removes the values of the fields which are deleted from the content type from its entries,
+// like the API does once the content type is activated without them.
+func (s *Server) dropDeletedFields(parent string, ct document) {
+	for _, entry := range s.sorted(parent + "/entries") {
+		if idOf(sysOf(entry)["contentType"]) != idOf(ct) {
+			continue
+		}
+		fields, _ := entry["fields"].(map[string]interface{})
+		for id := range fields {
+			if contentTypeField(ct, id) == nil {
+				delete(fields, id)
+			}
+		}
+	}
+}
+
+func (s *Server) unpublish(parent, typ string, doc document) error {
+	if !isPublished(doc) {
+		return errBadRequest("Not published")
+	}
+	if typ == "ContentType" {
+		for _, entry := range s.sorted(parent + "/entries") {
+			if idOf(sysOf(entry)["contentType"]) == idOf(doc) {
+				return errBadRequest("Cannot deactivate a content type which has entries. Delete its entries first.")
+			}
+		}
+	}
+
+	sys := sysOf(doc)
+	delete(sys, "publishedVersion")
+	delete(sys, "publishedAt")
+	touch(doc)
+	return nil
+}
+
+func archive(doc document) error {
+	if isPublished(doc) {
+		return errBadRequest("Cannot archive published entity")
+	}
+	if isArchived(doc) {
+		return errBadRequest("Already archived")
+	}
+
+	sys := sysOf(doc)
+	sys["archivedVersion"] = intOf(sys["version"])
+	sys["archivedAt"] = timestamp()
+	touch(doc)
+	return nil
+}
+
+func unarchive(doc document) error {
+	if !isArchived(doc) {
+		return errBadRequest("Not archived")
+	}
+
+	sys := sysOf(doc)
+	delete(sys, "archivedVersion")
+	delete(sys, "archivedAt")
+	touch(doc)
+	return nil
+}
+
+// process turns the upload URL of the asset file into a URL on the assets CDN.
+// Unlike the API, processing finishes before the response is sent.
+func process(doc document, locale string) error {
+	fields, _ := doc["fields"].(map[string]interface{})
+	files, _ := fields["file"].(map[string]interface{})
+	file, _ := files[locale].(map[string]interface{})
+	if file == nil {
+		return errValidationFailed(errorDetail{Name: "required", Path: []interface{}{"fields", "file", locale}, Details: "The property \"file\" is required here"})
+	}
+
+	if str(file["upload"]) == "" {
+		if str(file["url"]) != "" {
+			return nil
+		}
+		return errValidationFailed(errorDetail{Name: "required", Path: []interface{}{"fields", "file", locale, "upload"}, Details: "The property \"upload\" is required here"})
+	}
+
+	spaceID := idOf(sysOf(doc)["space"])
+	file["url"] = fmt.Sprintf("//images.ctfassets.net/%s/%s/%s/%s", spaceID, idOf(doc), newID(), str(file["fileName"]))
+	file["details"] = map[string]interface{}{"size": 0}
+	delete(file, "upload")
+	touch(doc)
+	return nil
+}
+
+// handleEditorInterface serves the editor interface of a content type. The API creates it with the content type,
+// so a content type without a stored editor interface has one with a control of each field.
+func (s *Server) handleEditorInterface(r *request, parent string, ct document) (int, interface{}, error) {
+	collection := parent + "/content_types/" + idOf(ct) + "/editor_interface"
+	doc := s.get(collection, "default")
+	if doc == nil {
+		sys := newSys(parent, "EditorInterface", "default")
+		sys["contentType"] = link("ContentType", idOf(ct))
+		controls := make([]interface{}, 0)
+		fields, _ := ct["fields"].([]interface{})
+		for _, v := range fields {
+			field, _ := v.(map[string]interface{})
+			controls = append(controls, map[string]interface{}{"fieldId": fieldAPIName(field)})
+		}
+		doc = document{"sys": sys, "controls": controls}
+		s.put(collection, "default", doc)
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		return http.StatusOK, doc, nil
+	case http.MethodPut:
+		if err := checkVersion(r, doc); err != nil {
+			return 0, nil, err
+		}
+		for k := range doc {
+			if k != "sys" {
+				delete(doc, k)
+			}
+		}
+		for k, v := range withoutSys(r.body) {
+			doc[k] = v
+		}
+		touch(doc)
+		return http.StatusOK, doc, nil
+	}
+	return 0, nil, errNotFound()
+}
+
+func isPublished(doc document) bool {
+	return intOf(sysOf(doc)["publishedVersion"]) != 0
+}
+
+func isArchived(doc document) bool {
+	return str(sysOf(doc)["archivedAt"]) != ""
+}
+
+// contentTypeField returns the field which entries name id. Entries use the apiName of renamed fields.
+func contentTypeField(ct document, id string) map[string]interface{} {
+	fields, _ := ct["fields"].([]interface{})
+	for _, v := range fields {
+		field, _ := v.(map[string]interface{})
+		if fieldAPIName(field) == id {
+			return field
+		}
+	}
+	return nil
+}
+
+func fieldAPIName(field map[string]interface{}) string {
+	if apiName := str(field["apiName"]); apiName != "" {
+		return apiName
+	}
+	return str(field["id"])
+}
+
+func (s *Server) localeCodes(envPath string) map[string]bool {
+	codes := make(map[string]bool)
+	for _, locale := range s.sorted(envPath + "/locales") {
+		codes[str(locale["code"])] = true
+	}
+	return codes
+}
+
+func (s *Server) defaultLocale(envPath string) string {
+	for _, locale := range s.sorted(envPath + "/locales") {
+		if locale["default"] == true {
+			return str(locale["code"])
+		}
+	}
+	return "en-US"
+}
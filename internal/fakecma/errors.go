@@ -0,0 +1,55 @@
+package fakecma
+
+import "net/http"
+
+// apiError is an error response of the API.
+type apiError struct {
+	status  int
+	id      string
+	message string
+	details []errorDetail
+}
+
+// errorDetail describes a single invalid property of a ValidationFailed error.
+type errorDetail struct {
+	Name    string        `json:"name"`
+	Path    []interface{} `json:"path"`
+	Details string        `json:"details,omitempty"`
+	Value   interface{}   `json:"value,omitempty"`
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func (e *apiError) response() document {
+	res := document{
+		"sys":       document{"type": "Error", "id": e.id},
+		"message":   e.message,
+		"requestId": newID(),
+	}
+	if len(e.details) > 0 {
+		res["details"] = document{"errors": e.details}
+	}
+	return res
+}
+
+func errNotFound() *apiError {
+	return &apiError{status: http.StatusNotFound, id: "NotFound", message: "The resource could not be found."}
+}
+
+func errAccessTokenInvalid() *apiError {
+	return &apiError{status: http.StatusUnauthorized, id: "AccessTokenInvalid", message: "The access token you sent could not be found or is invalid."}
+}
+
+func errVersionMismatch() *apiError {
+	return &apiError{status: http.StatusConflict, id: "VersionMismatch", message: "The version you sent does not match the current version of the resource."}
+}
+
+func errBadRequest(message string) *apiError {
+	return &apiError{status: http.StatusBadRequest, id: "BadRequest", message: message}
+}
+
+func errValidationFailed(details ...errorDetail) *apiError {
+	return &apiError{status: http.StatusUnprocessableEntity, id: "ValidationFailed", message: "Validation error", details: details}
+}
@@ -0,0 +1,559 @@
+// Package fakecma is an in-process fake of the Contentful Content Management API.
+//
+// It keeps every resource in memory and mimics the versioning, publishing and error responses
+// of the real API closely enough to run the provider's acceptance tests without a Contentful account.
+package fakecma
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// document is a resource as it is sent over the wire.
+type document map[string]interface{}
+
+// Server is a fake Content Management API listening on a local address.
+// Point the contentful-go client at it by setting its BaseURL to URL.
+type Server struct {
+	URL string
+
+	server *httptest.Server
+	mu     sync.Mutex
+	// collections maps the path of a collection, such as /spaces/s/environments/e/entries, to its documents by ID.
+	collections map[string]map[string]document
+}
+
+// NewServer starts a fake Content Management API without any space.
+func NewServer() *Server {
+	s := &Server{
+		collections: make(map[string]map[string]document),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// AddSpace creates a space with a master environment and an en-US default locale.
+func (s *Server) AddSpace(spaceID, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.createSpace(spaceID, name, "en-US")
+}
+
+// AddEnvironment creates an environment as a copy of the master environment of an existing space.
+func (s *Server) AddEnvironment(spaceID, environmentID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.createEnvironment(spaceID, environmentID, environmentID)
+}
+
+// request is an incoming API call split into the segments of its path.
+type request struct {
+	*http.Request
+	segments []string
+	body     document
+}
+
+func (r *request) version() (int, bool) {
+	v, err := strconv.Atoi(r.Header.Get("X-Contentful-Version"))
+	return v, err == nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	status, v, err := s.handle(r)
+	if err != nil {
+		apiErr, ok := err.(*apiError)
+		if !ok {
+			apiErr = &apiError{status: http.StatusInternalServerError, id: "InternalServerError", message: err.Error()}
+		}
+		status, v = apiErr.status, apiErr.response()
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.contentful.management.v1+json")
+	if v == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (s *Server) handle(r *http.Request) (int, interface{}, error) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		return 0, nil, errAccessTokenInvalid()
+	}
+
+	req := &request{Request: r, segments: canonicalSegments(r.URL.Path)}
+	if r.Body != nil {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			return 0, nil, err
+		}
+		if len(b) > 0 {
+			if err := json.Unmarshal(b, &req.body); err != nil {
+				return 0, nil, errBadRequest("The request body is not valid JSON")
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.route(req)
+}
+
+// environmentScoped are collections which the API also serves under /spaces/{id} for the master environment.
+var environmentScoped = map[string]bool{
+	"entries":       true,
+	"assets":        true,
+	"content_types": true,
+	"locales":       true,
+	"tags":          true,
+}
+
+// canonicalSegments splits the path, rewriting space level aliases of the master environment.
+func canonicalSegments(path string) []string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) >= 3 && segments[0] == "spaces" && environmentScoped[segments[2]] {
+		segments = append([]string{"spaces", segments[1], "environments", "master"}, segments[2:]...)
+	}
+	return segments
+}
+
+func (s *Server) route(r *request) (int, interface{}, error) {
+	seg := r.segments
+	if len(seg) == 0 || seg[0] != "spaces" {
+		return 0, nil, errNotFound()
+	}
+
+	if len(seg) == 1 {
+		if r.Method != http.MethodPost {
+			return 0, nil, errNotFound()
+		}
+		return s.postSpace(r)
+	}
+
+	spaceID := seg[1]
+	if len(seg) == 2 {
+		return s.handleSpace(r, spaceID)
+	}
+	if s.get("/spaces", spaceID) == nil {
+		return 0, nil, errNotFound()
+	}
+
+	spacePath := "/spaces/" + spaceID
+	switch {
+	case seg[2] == "environments" && len(seg) == 4:
+		return s.handleEnvironment(r, spaceID, seg[3])
+	case seg[2] == "environments" && len(seg) > 4:
+		envPath := spacePath + "/environments/" + seg[3]
+		if s.get(spacePath+"/environments", seg[3]) == nil {
+			return 0, nil, errNotFound()
+		}
+		switch seg[4] {
+		case "bulk_actions":
+			return s.handleBulkAction(r, envPath, seg[5:])
+		case "releases":
+			return s.handleRelease(r, envPath, seg[5:])
+		}
+		return s.handleCollection(r, envPath, seg[4:])
+	case seg[2] == "scheduled_actions":
+		return s.handleScheduledAction(r, spaceID, seg[3:])
+	}
+	return s.handleCollection(r, spacePath, seg[2:])
+}
+
+// documentTypes are the sys.type of the documents of each generic collection.
+var documentTypes = map[string]string{
+	"entries":             "Entry",
+	"assets":              "Asset",
+	"content_types":       "ContentType",
+	"locales":             "Locale",
+	"webhook_definitions": "WebhookDefinition",
+	"api_keys":            "ApiKey",
+	"tags":                "Tag",
+}
+
+func (s *Server) handleCollection(r *request, parent string, rest []string) (int, interface{}, error) {
+	typ, ok := documentTypes[rest[0]]
+	if !ok {
+		return 0, nil, errNotFound()
+	}
+	collection := parent + "/" + rest[0]
+
+	if len(rest) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			return http.StatusOK, s.list(r, collection), nil
+		case http.MethodPost:
+			return s.putDocument(r, collection, typ, newID())
+		}
+		return 0, nil, errNotFound()
+	}
+
+	id := rest[1]
+	if len(rest) == 2 {
+		switch r.Method {
+		case http.MethodGet:
+			doc := s.get(collection, id)
+			if doc == nil {
+				return 0, nil, errNotFound()
+			}
+			return http.StatusOK, doc, nil
+		case http.MethodPut:
+			return s.putDocument(r, collection, typ, id)
+		case http.MethodDelete:
+			return s.deleteDocument(r, collection, typ, id)
+		}
+		return 0, nil, errNotFound()
+	}
+
+	doc := s.get(collection, id)
+	if doc == nil {
+		return 0, nil, errNotFound()
+	}
+	if len(rest) == 3 && rest[2] == "editor_interface" && typ == "ContentType" {
+		return s.handleEditorInterface(r, parent, doc)
+	}
+	if err := checkVersion(r, doc); err != nil {
+		return 0, nil, err
+	}
+
+	var err error
+	switch {
+	case len(rest) == 3 && rest[2] == "published" && (typ == "Entry" || typ == "Asset" || typ == "ContentType"):
+		if r.Method == http.MethodPut {
+			err = s.publish(parent, typ, doc)
+		} else if r.Method == http.MethodDelete {
+			err = s.unpublish(parent, typ, doc)
+		}
+	case len(rest) == 3 && rest[2] == "archived" && (typ == "Entry" || typ == "Asset"):
+		if r.Method == http.MethodPut {
+			err = archive(doc)
+		} else if r.Method == http.MethodDelete {
+			err = unarchive(doc)
+		}
+	case len(rest) == 5 && rest[2] == "files" && rest[4] == "process" && typ == "Asset" && r.Method == http.MethodPut:
+		err = process(doc, rest[3])
+	default:
+		return 0, nil, errNotFound()
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, doc, nil
+}
+
+// putDocument creates a document with the given ID or updates it when it already exists.
+func (s *Server) putDocument(r *request, collection, typ, id string) (int, interface{}, error) {
+	parent := strings.TrimSuffix(collection, "/"+collectionName(collection))
+	body := withoutSys(r.body)
+
+	doc := s.get(collection, id)
+	if doc == nil {
+		sys := newSys(parent, typ, id)
+		if typ == "Entry" {
+			contentTypeID := r.Header.Get("X-Contentful-Content-Type")
+			if contentTypeID == "" {
+				return 0, nil, errBadRequest("The X-Contentful-Content-Type header is required to create an entry")
+			}
+			sys["contentType"] = link("ContentType", contentTypeID)
+		}
+		if typ == "Tag" {
+			// Unlike other documents, tags are created with a property of sys.
+			sys["visibility"] = "private"
+			if visibility := str(sysOf(r.body)["visibility"]); visibility != "" {
+				sys["visibility"] = visibility
+			}
+		}
+		doc = document{"sys": sys}
+		if err := s.validateDocument(parent, typ, doc, body); err != nil {
+			return 0, nil, err
+		}
+		for k, v := range defaults(typ, parent) {
+			doc[k] = v
+		}
+		for k, v := range body {
+			doc[k] = v
+		}
+		s.put(collection, id, doc)
+		return http.StatusCreated, doc, nil
+	}
+
+	if err := checkVersion(r, doc); err != nil {
+		return 0, nil, err
+	}
+	if err := s.validateDocument(parent, typ, doc, body); err != nil {
+		return 0, nil, err
+	}
+	for k := range doc {
+		if k != "sys" {
+			delete(doc, k)
+		}
+	}
+	for k, v := range body {
+		doc[k] = v
+	}
+	touch(doc)
+	return http.StatusOK, doc, nil
+}
+
+func (s *Server) deleteDocument(r *request, collection, typ, id string) (int, interface{}, error) {
+	doc := s.get(collection, id)
+	if doc == nil {
+		return 0, nil, errNotFound()
+	}
+	if _, ok := r.version(); ok {
+		if err := checkVersion(r, doc); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	switch typ {
+	case "Entry", "Asset":
+		if isPublished(doc) {
+			return 0, nil, errBadRequest("Cannot delete published " + strings.ToLower(typ))
+		}
+	case "ContentType":
+		if isPublished(doc) {
+			return 0, nil, errBadRequest("Cannot delete a content type which is still active. Deactivate it first.")
+		}
+	}
+
+	s.deleteTree(collection, id)
+	return http.StatusNoContent, nil, nil
+}
+
+// list serves a collection, filtered by the query parameters the provider relies on.
+func (s *Server) list(r *request, collection string) document {
+	query := r.URL.Query()
+
+	var ids map[string]bool
+	if v := query.Get("sys.id[in]"); v != "" {
+		ids = make(map[string]bool)
+		for _, id := range strings.Split(v, ",") {
+			ids[id] = true
+		}
+	}
+	contentType := query.Get("content_type")
+	if contentType == "" {
+		contentType = query.Get("sys.contentType.sys.id")
+	}
+
+	items := make([]interface{}, 0)
+	for _, doc := range s.sorted(collection) {
+		sys := sysOf(doc)
+		if ids != nil && !ids[str(sys["id"])] {
+			continue
+		}
+		if contentType != "" && idOf(sys["contentType"]) != contentType {
+			continue
+		}
+		items = append(items, doc)
+	}
+
+	total := len(items)
+	skip, _ := strconv.Atoi(query.Get("skip"))
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil {
+		limit = 100
+	}
+	if skip > len(items) {
+		skip = len(items)
+	}
+	items = items[skip:]
+	if limit < len(items) {
+		items = items[:limit]
+	}
+
+	return document{
+		"sys":   document{"type": "Array"},
+		"total": total,
+		"skip":  skip,
+		"limit": limit,
+		"items": items,
+	}
+}
+
+func (s *Server) get(collection, id string) document {
+	return s.collections[collection][id]
+}
+
+func (s *Server) put(collection, id string, doc document) {
+	if s.collections[collection] == nil {
+		s.collections[collection] = make(map[string]document)
+	}
+	s.collections[collection][id] = doc
+}
+
+// sorted returns the documents of a collection ordered by ID, so that listings are stable.
+func (s *Server) sorted(collection string) []document {
+	ids := make([]string, 0, len(s.collections[collection]))
+	for id := range s.collections[collection] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	docs := make([]document, 0, len(ids))
+	for _, id := range ids {
+		docs = append(docs, s.collections[collection][id])
+	}
+	return docs
+}
+
+// deleteTree removes the document at path and every collection nested below it.
+func (s *Server) deleteTree(collection, id string) {
+	delete(s.collections[collection], id)
+	prefix := collection + "/" + id + "/"
+	for path := range s.collections {
+		if strings.HasPrefix(path, prefix) {
+			delete(s.collections, path)
+		}
+	}
+}
+
+func collectionName(collection string) string {
+	return collection[strings.LastIndex(collection, "/")+1:]
+}
+
+// newSys returns the sys of a new document in the collection below parent.
+func newSys(parent, typ, id string) document {
+	now := timestamp()
+	sys := document{
+		"id":        id,
+		"type":      typ,
+		"version":   1,
+		"createdAt": now,
+		"updatedAt": now,
+	}
+
+	seg := strings.Split(strings.Trim(parent, "/"), "/")
+	if len(seg) >= 2 && seg[0] == "spaces" {
+		sys["space"] = link("Space", seg[1])
+	}
+	if len(seg) >= 4 && seg[2] == "environments" {
+		sys["environment"] = link("Environment", seg[3])
+	}
+	return sys
+}
+
+// defaults are the properties the API fills in when a document is created without them.
+func defaults(typ, parent string) document {
+	switch typ {
+	case "ApiKey":
+		previewID := newID()
+		return document{
+			"accessToken":     newID() + newID(),
+			"preview_api_key": link("PreviewApiKey", previewID),
+			"environments":    []interface{}{link("Environment", "master")},
+		}
+	case "Locale":
+		return document{
+			"contentDeliveryApi":   true,
+			"contentManagementApi": true,
+			"optional":             false,
+			"default":              false,
+		}
+	}
+	return nil
+}
+
+func withoutSys(body document) document {
+	doc := make(document, len(body))
+	for k, v := range body {
+		if k != "sys" {
+			doc[k] = v
+		}
+	}
+	return doc
+}
+
+// touch records an update of the document.
+func touch(doc document) {
+	sys := sysOf(doc)
+	sys["version"] = intOf(sys["version"]) + 1
+	sys["updatedAt"] = timestamp()
+}
+
+func checkVersion(r *request, doc document) error {
+	v, _ := r.version()
+	if v != intOf(sysOf(doc)["version"]) {
+		return errVersionMismatch()
+	}
+	return nil
+}
+
+func link(linkType, id string) document {
+	return document{"sys": document{"type": "Link", "linkType": linkType, "id": id}}
+}
+
+// idOf returns the sys.id of a document or a link.
+func idOf(v interface{}) string {
+	switch l := v.(type) {
+	case document:
+		return str(sysOf(l)["id"])
+	case map[string]interface{}:
+		return str(sysOf(l)["id"])
+	}
+	return ""
+}
+
+func sysOf(doc map[string]interface{}) map[string]interface{} {
+	switch sys := doc["sys"].(type) {
+	case document:
+		return sys
+	case map[string]interface{}:
+		return sys
+	}
+	return map[string]interface{}{}
+}
+
+func str(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+// intOf reads a number which is an int when set by the server and a float64 when decoded from JSON.
+func intOf(v interface{}) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	}
+	return 0
+}
+
+// clone deep copies a document through JSON.
+func clone(doc document) document {
+	b, _ := json.Marshal(doc)
+	var c document
+	_ = json.Unmarshal(b, &c)
+	return c
+}
+
+func newID() string {
+	b := make([]byte, 11)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+}
@@ -0,0 +1,227 @@
+package fakecma
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	contentful "github.com/kitagry/contentful-go"
+)
+
+func newTestClient(t *testing.T) (*contentful.Client, *contentful.Environment) {
+	t.Helper()
+
+	server := NewServer()
+	t.Cleanup(server.Close)
+	server.AddSpace("space", "Test Space")
+
+	client := contentful.NewCMA("token")
+	client.BaseURL = server.URL
+
+	env, err := client.Environments.Get(context.Background(), "space", "master")
+	if err != nil {
+		t.Fatalf("failed to get environment: %v", err)
+	}
+	return client, env
+}
+
+func newTestContentType(t *testing.T, client *contentful.Client, env *contentful.Environment) *contentful.ContentType {
+	t.Helper()
+	ctx := context.Background()
+
+	ct := &contentful.ContentType{
+		Sys:          &contentful.Sys{ID: "post"},
+		Name:         "Post",
+		DisplayField: "title",
+		Fields: []*contentful.Field{
+			{ID: "title", Name: "Title", Type: "Symbol", Required: true},
+			{ID: "body", Name: "Body", Type: "Text"},
+		},
+	}
+	if err := client.ContentTypes.Upsert(ctx, env, ct); err != nil {
+		t.Fatalf("failed to create content type: %v", err)
+	}
+	if err := client.ContentTypes.Activate(ctx, env, ct); err != nil {
+		t.Fatalf("failed to activate content type: %v", err)
+	}
+	return ct
+}
+
+func TestServer_EntryLifecycle(t *testing.T) {
+	ctx := context.Background()
+	client, env := newTestClient(t)
+	newTestContentType(t, client, env)
+
+	entry := &contentful.Entry{
+		Sys:    &contentful.Sys{ID: "hello"},
+		Fields: map[string]interface{}{"title": map[string]interface{}{"en-US": "Hello"}},
+	}
+	if err := client.Entries.Upsert(ctx, env, "post", entry); err != nil {
+		t.Fatalf("failed to create entry: %v", err)
+	}
+	if entry.Sys.Version != 1 || entry.Sys.ContentType.Sys.ID != "post" || entry.Sys.Space.Sys.ID != "space" {
+		t.Errorf("unexpected sys of created entry: %+v", entry.Sys)
+	}
+
+	if err := client.Entries.Publish(ctx, env, entry); err != nil {
+		t.Fatalf("failed to publish entry: %v", err)
+	}
+	entry, _ = client.Entries.Get(ctx, env, "hello")
+	if entry.Sys.PublishedVersion != 1 || entry.Sys.Version != 2 {
+		t.Errorf("published entry should have publishedVersion 1 and version 2, got %d and %d", entry.Sys.PublishedVersion, entry.Sys.Version)
+	}
+
+	if err := client.Entries.Archive(ctx, env, entry); err == nil {
+		t.Error("archiving a published entry should fail")
+	}
+
+	if err := client.Entries.Unpublish(ctx, env, entry); err != nil {
+		t.Fatalf("failed to unpublish entry: %v", err)
+	}
+	entry, _ = client.Entries.Get(ctx, env, "hello")
+	if err := client.Entries.Archive(ctx, env, entry); err != nil {
+		t.Fatalf("failed to archive entry: %v", err)
+	}
+	entry, _ = client.Entries.Get(ctx, env, "hello")
+	if entry.Sys.ArchivedAt == "" || entry.Sys.PublishedAt != "" {
+		t.Errorf("entry should be archived and unpublished: %+v", entry.Sys)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	ctx := context.Background()
+	client, env := newTestClient(t)
+	ct := newTestContentType(t, client, env)
+
+	t.Run("missing resource should be NotFound", func(t *testing.T) {
+		_, err := client.ContentTypes.Get(ctx, env, "unknown")
+		if _, ok := err.(contentful.NotFoundError); !ok {
+			t.Errorf("expected NotFoundError, got %T: %v", err, err)
+		}
+	})
+
+	t.Run("outdated version should be VersionMismatch", func(t *testing.T) {
+		outdated := *ct
+		outdated.Sys = &contentful.Sys{ID: ct.Sys.ID, Version: ct.Sys.Version - 1}
+		err := client.ContentTypes.Upsert(ctx, env, &outdated)
+		if _, ok := err.(contentful.VersionMismatchError); !ok {
+			t.Errorf("expected VersionMismatchError, got %T: %v", err, err)
+		}
+	})
+
+	t.Run("publishing without required field should be ValidationFailed with its path", func(t *testing.T) {
+		entry := &contentful.Entry{
+			Sys:    &contentful.Sys{ID: "untitled"},
+			Fields: map[string]interface{}{"body": map[string]interface{}{"en-US": "no title"}},
+		}
+		if err := client.Entries.Upsert(ctx, env, "post", entry); err != nil {
+			t.Fatalf("failed to create entry: %v", err)
+		}
+
+		err := client.Entries.Publish(ctx, env, entry)
+		v, ok := err.(contentful.ValidationFailedError)
+		if !ok {
+			t.Fatalf("expected ValidationFailedError, got %T: %v", err, err)
+		}
+		res, _ := v.ErrorResponse()
+		expect := []interface{}{"fields", "title", "en-US"}
+		if diff := cmp.Diff(expect, res.Details.Errors[0].Path); diff != "" {
+			t.Errorf("error path diff (-expect, +got)\n%s", diff)
+		}
+	})
+
+	t.Run("deactivating content type with entries should fail", func(t *testing.T) {
+		ct, _ := client.ContentTypes.Get(ctx, env, "post")
+		if err := client.ContentTypes.Deactivate(ctx, env, ct); err == nil {
+			t.Error("deactivating a content type with entries should fail")
+		}
+	})
+}
+
+func TestServer_Environment(t *testing.T) {
+	ctx := context.Background()
+	client, env := newTestClient(t)
+	newTestContentType(t, client, env)
+
+	staging := &contentful.Environment{Name: "staging"}
+	if err := client.Environments.Upsert(ctx, "space", staging); err != nil {
+		t.Fatalf("failed to create environment: %v", err)
+	}
+
+	ct, err := client.ContentTypes.Get(ctx, staging, "post")
+	if err != nil {
+		t.Fatalf("content types should be copied from master: %v", err)
+	}
+	if ct.Sys.PublishedVersion == 0 {
+		t.Error("copied content type should stay active")
+	}
+
+	if err := client.Environments.Delete(ctx, "space", staging); err != nil {
+		t.Fatalf("failed to delete environment: %v", err)
+	}
+	if _, err := client.ContentTypes.Get(ctx, staging, "post"); err == nil {
+		t.Error("content types should be deleted with the environment")
+	}
+}
+
+func TestServer_EditorInterface(t *testing.T) {
+	client, env := newTestClient(t)
+	newTestContentType(t, client, env)
+	url := client.BaseURL + "/spaces/space/environments/master/content_types/post/editor_interface"
+
+	send := func(method string, version int, body string) (int, map[string]interface{}) {
+		req, _ := http.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer token")
+		req.Header.Set("X-Contentful-Version", strconv.Itoa(version))
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		var doc map[string]interface{}
+		_ = json.NewDecoder(res.Body).Decode(&doc)
+		return res.StatusCode, doc
+	}
+
+	_, ei := send(http.MethodGet, 0, "")
+	if controls, _ := ei["controls"].([]interface{}); len(controls) != 2 {
+		t.Errorf("editor interface should have a control of each field, got %v", ei["controls"])
+	}
+
+	status, ei := send(http.MethodPut, 1, `{"controls":[{"fieldId":"body","widgetNamespace":"builtin","widgetId":"markdown"}]}`)
+	if status != http.StatusOK || intOf(sysOf(ei)["version"]) != 2 {
+		t.Errorf("update should increase the version, got %d %v", status, ei)
+	}
+
+	if status, _ := send(http.MethodPut, 1, `{"controls":[]}`); status != http.StatusConflict {
+		t.Errorf("update with an old version should conflict, got %d", status)
+	}
+}
+
+func TestServer_Tags(t *testing.T) {
+	client, _ := newTestClient(t)
+	put := func(id, body string) (int, map[string]interface{}) {
+		req, _ := http.NewRequest(http.MethodPut, client.BaseURL+"/spaces/space/environments/master/tags/"+id, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer token")
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		var doc map[string]interface{}
+		_ = json.NewDecoder(res.Body).Decode(&doc)
+		return res.StatusCode, doc
+	}
+
+	status, tag := put("news", `{"name":"News","sys":{"id":"news","visibility":"public"}}`)
+	if status != http.StatusCreated || sysOf(tag)["visibility"] != "public" {
+		t.Errorf("tag should be created with its visibility, got %d %v", status, tag)
+	}
+	if status, _ := put("other", `{"name":"News"}`); status != http.StatusUnprocessableEntity {
+		t.Errorf("tag with a taken name should fail, got %d", status)
+	}
+}
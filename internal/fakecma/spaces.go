@@ -0,0 +1,135 @@
+package fakecma
+
+import (
+	"net/http"
+	"strings"
+)
+
+func (s *Server) createSpace(spaceID, name, defaultLocale string) document {
+	space := document{
+		"sys":  newSys("", "Space", spaceID),
+		"name": name,
+	}
+	s.put("/spaces", spaceID, space)
+
+	spacePath := "/spaces/" + spaceID
+	envSys := newSys(spacePath, "Environment", "master")
+	envSys["status"] = link("Status", "ready")
+	s.put(spacePath+"/environments", "master", document{"sys": envSys, "name": "master"})
+
+	envPath := spacePath + "/environments/master"
+	localeID := newID()
+	locale := document{"sys": newSys(envPath, "Locale", localeID)}
+	for k, v := range defaults("Locale", envPath) {
+		locale[k] = v
+	}
+	locale["name"] = defaultLocale
+	locale["code"] = defaultLocale
+	locale["default"] = true
+	s.put(envPath+"/locales", localeID, locale)
+
+	return space
+}
+
+// createEnvironment copies the locales, content types, entries and assets of the master environment,
+// as the API does when an environment is created without a source environment.
+func (s *Server) createEnvironment(spaceID, environmentID, name string) document {
+	spacePath := "/spaces/" + spaceID
+	sys := newSys(spacePath, "Environment", environmentID)
+	sys["status"] = link("Status", "ready")
+	env := document{"sys": sys, "name": name}
+	s.put(spacePath+"/environments", environmentID, env)
+
+	masterPath := spacePath + "/environments/master"
+	envPath := spacePath + "/environments/" + environmentID
+	for collection := range environmentScoped {
+		for _, doc := range s.sorted(masterPath + "/" + collection) {
+			c := clone(doc)
+			sysOf(c)["environment"] = link("Environment", environmentID)
+			s.put(envPath+"/"+collection, str(sysOf(c)["id"]), c)
+		}
+	}
+	return env
+}
+
+func (s *Server) postSpace(r *request) (int, interface{}, error) {
+	if r.Header.Get("X-Contentful-Organization") == "" {
+		return 0, nil, errBadRequest("The X-Contentful-Organization header is required to create a space")
+	}
+
+	defaultLocale := str(r.body["defaultLocale"])
+	if defaultLocale == "" {
+		defaultLocale = "en-US"
+	}
+	return http.StatusCreated, s.createSpace(newID(), str(r.body["name"]), defaultLocale), nil
+}
+
+func (s *Server) handleSpace(r *request, spaceID string) (int, interface{}, error) {
+	space := s.get("/spaces", spaceID)
+	if space == nil {
+		return 0, nil, errNotFound()
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		return http.StatusOK, space, nil
+	case http.MethodPut:
+		if err := checkVersion(r, space); err != nil {
+			return 0, nil, err
+		}
+		space["name"] = r.body["name"]
+		touch(space)
+		return http.StatusOK, space, nil
+	case http.MethodDelete:
+		if _, ok := r.version(); ok {
+			if err := checkVersion(r, space); err != nil {
+				return 0, nil, err
+			}
+		}
+		s.deleteTree("/spaces", spaceID)
+		return http.StatusNoContent, nil, nil
+	}
+	return 0, nil, errNotFound()
+}
+
+func (s *Server) handleEnvironment(r *request, spaceID, environmentID string) (int, interface{}, error) {
+	collection := "/spaces/" + spaceID + "/environments"
+	env := s.get(collection, environmentID)
+
+	switch r.Method {
+	case http.MethodGet:
+		if env == nil {
+			return 0, nil, errNotFound()
+		}
+		return http.StatusOK, env, nil
+	case http.MethodPut:
+		name := str(r.body["name"])
+		if strings.TrimSpace(name) == "" {
+			return 0, nil, errValidationFailed(errorDetail{Name: "required", Path: []interface{}{"name"}, Details: "The property \"name\" is required here"})
+		}
+		if env == nil {
+			return http.StatusCreated, s.createEnvironment(spaceID, environmentID, name), nil
+		}
+		if err := checkVersion(r, env); err != nil {
+			return 0, nil, err
+		}
+		env["name"] = name
+		touch(env)
+		return http.StatusOK, env, nil
+	case http.MethodDelete:
+		if env == nil {
+			return 0, nil, errNotFound()
+		}
+		if environmentID == "master" {
+			return 0, nil, errBadRequest("The master environment cannot be deleted")
+		}
+		if _, ok := r.version(); ok {
+			if err := checkVersion(r, env); err != nil {
+				return 0, nil, err
+			}
+		}
+		s.deleteTree(collection, environmentID)
+		return http.StatusNoContent, nil, nil
+	}
+	return 0, nil, errNotFound()
+}
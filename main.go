@@ -1,15 +1,79 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/kitagry/terraform-provider-contentful/contentful"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := export(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "export: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: func() *schema.Provider {
 			return contentful.Provider()
 		},
 	})
 }
+
+// export writes the Terraform configuration of an environment. The token is only read from
+// CONTENTFUL_MANAGEMENT_TOKEN, so that it does not end up in the shell history.
+func export(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s export -space-id <space_id> [-environment-id <env_id>] [-o <file>]\n\n", os.Args[0])
+		fmt.Fprintln(fs.Output(), "Writes resource and import blocks of an environment. The token is read from CONTENTFUL_MANAGEMENT_TOKEN.")
+		fs.PrintDefaults()
+	}
+	spaceID := fs.String("space-id", os.Getenv("CONTENTFUL_SPACE_ID"), "the ID of the space")
+	environmentID := fs.String("environment-id", envOr("CONTENTFUL_ENVIRONMENT_ID", "master"), "the ID of the environment")
+	baseURL := fs.String("base-url", envOr("CONTENTFUL_BASE_URL", "https://api.contentful.com"), "the base URL of the Content Management API")
+	output := fs.String("o", "", "the file to write the configuration to. Defaults to the standard output")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	token := os.Getenv("CONTENTFUL_MANAGEMENT_TOKEN")
+	if token == "" {
+		return fmt.Errorf("CONTENTFUL_MANAGEMENT_TOKEN is not set")
+	}
+	if *spaceID == "" {
+		return fmt.Errorf("-space-id is not set")
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return contentful.Export(context.Background(), w, contentful.ExportOptions{
+		CMAToken:      token,
+		BaseURL:       *baseURL,
+		SpaceID:       *spaceID,
+		EnvironmentID: *environmentID,
+	})
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}